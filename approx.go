@@ -0,0 +1,304 @@
+package matcher
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+)
+
+var errNotFloatExpectation = errors.New("expectation must be a number, or a slice, an array or a map of numbers")
+
+// floatComparator determines if two floats are close enough. The is32 flag tells if both values are float32.
+type floatComparator func(expected, actual float64, is32 bool) bool
+
+var (
+	_ Matcher   = (*approxMatcher)(nil)
+	_ Explainer = (*approxMatcher)(nil)
+)
+
+// approxMatcher matches numbers, or slices, arrays and maps of numbers, approximately.
+type approxMatcher struct {
+	expected  any
+	tolerance string
+	compare   floatComparator
+	operator  string
+	value     []any
+}
+
+// Match determines if the actual is expected.
+func (m approxMatcher) Match(actual any) (bool, error) {
+	return m.explain(m.expected, actual) == nil, nil
+}
+
+// Expected returns the expectation.
+func (m approxMatcher) Expected() string {
+	return m.expectedOf(m.expected)
+}
+
+// Explain explains why the actual is not expected.
+func (m approxMatcher) Explain(actual any) *Mismatch {
+	return m.explain(m.expected, actual)
+}
+
+func (m approxMatcher) Format(s fmt.State, _ rune) {
+	_, _ = fmt.Fprintf(s, "<%s>", m.Expected()) //nolint: errcheck
+}
+
+func (m approxMatcher) expectedOf(expected any) string {
+	return fmt.Sprintf("%s %v", m.tolerance, expected)
+}
+
+// nolint: exhaustive
+func (m approxMatcher) explain(expected, actual any) *Mismatch {
+	ev := indirectVal(reflect.ValueOf(expected))
+	av := indirectVal(reflect.ValueOf(actual))
+
+	mismatch := func(reason string, children ...*Mismatch) *Mismatch {
+		return &Mismatch{Expected: m.expectedOf(expected), Actual: actual, Reason: reason, Children: children}
+	}
+
+	switch ev.Kind() {
+	case reflect.Slice, reflect.Array:
+		if av.Kind() != reflect.Slice && av.Kind() != reflect.Array {
+			return mismatch(fmt.Sprintf("got %T, not a slice or an array", actual))
+		}
+
+		if av.Len() != ev.Len() {
+			return mismatch(fmt.Sprintf("got len %d", av.Len()))
+		}
+
+		var children []*Mismatch
+
+		for i := range ev.Len() {
+			if c := m.explain(ev.Index(i).Interface(), av.Index(i).Interface()); c != nil {
+				c.Path = fmt.Sprintf("[%d]", i)
+				children = append(children, c)
+			}
+		}
+
+		if len(children) > 0 {
+			return mismatch("", children...)
+		}
+
+		return nil
+
+	case reflect.Map:
+		if av.Kind() != reflect.Map || !ev.Type().Key().AssignableTo(av.Type().Key()) {
+			return mismatch(fmt.Sprintf("got %T, not a map of the same key type", actual))
+		}
+
+		if av.Len() != ev.Len() {
+			return mismatch(fmt.Sprintf("got len %d", av.Len()))
+		}
+
+		var children []*Mismatch
+
+		for _, k := range sortedMapKeys(ev) {
+			path := fmt.Sprintf("[%v]", k.Interface())
+
+			v := av.MapIndex(k)
+			if !v.IsValid() {
+				children = append(children, &Mismatch{Path: path, Expected: m.expectedOf(ev.MapIndex(k).Interface()), Reason: "key not found"})
+
+				continue
+			}
+
+			if c := m.explain(ev.MapIndex(k).Interface(), v.Interface()); c != nil {
+				c.Path = path
+				children = append(children, c)
+			}
+		}
+
+		if len(children) > 0 {
+			return mismatch("", children...)
+		}
+
+		return nil
+	}
+
+	if !isNumber(av) {
+		return mismatch(fmt.Sprintf("got %T, not a number", actual))
+	}
+
+	if !m.compare(floatVal(ev), floatVal(av), ev.Kind() == reflect.Float32 && av.Kind() == reflect.Float32) {
+		return mismatch("")
+	}
+
+	return nil
+}
+
+// validateFloats checks whether the value is a number or a collection of numbers.
+//
+// nolint: exhaustive
+func validateFloats(v reflect.Value) error {
+	v = indirectVal(v)
+
+	switch v.Kind() {
+	case reflect.Slice, reflect.Array:
+		for i := range v.Len() {
+			if err := validateFloats(v.Index(i)); err != nil {
+				return err
+			}
+		}
+
+		return nil
+
+	case reflect.Map:
+		for _, k := range v.MapKeys() {
+			if err := validateFloats(v.MapIndex(k)); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	if !isNumber(v) {
+		return errNotFloatExpectation
+	}
+
+	return nil
+}
+
+// compareSpecialFloats compares NaN and infinities. NaN only matches NaN and an infinity only matches the same
+// infinity. The handled flag is false if none of the values is special.
+func compareSpecialFloats(expected, actual float64) (matched bool, handled bool) {
+	switch {
+	case math.IsNaN(expected) || math.IsNaN(actual):
+		return math.IsNaN(expected) && math.IsNaN(actual), true
+
+	case math.IsInf(expected, 0) || math.IsInf(actual, 0):
+		return expected == actual, true
+	}
+
+	return false, false
+}
+
+func inDelta(delta float64) floatComparator {
+	return func(expected, actual float64, _ bool) bool {
+		if matched, ok := compareSpecialFloats(expected, actual); ok {
+			return matched
+		}
+
+		return math.Abs(expected-actual) <= delta
+	}
+}
+
+func inEpsilon(epsilon float64) floatComparator {
+	return func(expected, actual float64, _ bool) bool {
+		if matched, ok := compareSpecialFloats(expected, actual); ok {
+			return matched
+		}
+
+		if expected == 0 {
+			return actual == 0
+		}
+
+		return math.Abs(expected-actual)/math.Abs(expected) <= epsilon
+	}
+}
+
+func withinULP(ulps uint64) floatComparator {
+	return func(expected, actual float64, is32 bool) bool {
+		if matched, ok := compareSpecialFloats(expected, actual); ok {
+			return matched
+		}
+
+		if expected == actual {
+			return true
+		}
+
+		if is32 {
+			return ulpDistance32(float32(expected), float32(actual)) <= ulps
+		}
+
+		return ulpDistance64(expected, actual) <= ulps
+	}
+}
+
+// ulpDistance64 counts the representable float64 values between a and b.
+func ulpDistance64(a, b float64) uint64 {
+	ia, ib := orderedBits64(a), orderedBits64(b)
+
+	if ia > ib {
+		return ia - ib
+	}
+
+	return ib - ia
+}
+
+// orderedBits64 maps a float64 to an uint64 that has the same order. Both zeros are mapped to the same value.
+func orderedBits64(f float64) uint64 {
+	const sign = 1 << 63
+
+	b := math.Float64bits(f)
+
+	if b&sign != 0 {
+		return sign - b&^sign
+	}
+
+	return sign + b
+}
+
+// ulpDistance32 counts the representable float32 values between a and b.
+func ulpDistance32(a, b float32) uint64 {
+	ia, ib := orderedBits32(a), orderedBits32(b)
+
+	if ia > ib {
+		return uint64(ia - ib)
+	}
+
+	return uint64(ib - ia)
+}
+
+// orderedBits32 maps a float32 to an uint32 that has the same order. Both zeros are mapped to the same value.
+func orderedBits32(f float32) uint32 {
+	const sign = 1 << 31
+
+	b := math.Float32bits(f)
+
+	if b&sign != 0 {
+		return sign - b&^sign
+	}
+
+	return sign + b
+}
+
+func newApproxMatcher(operator string, expected, threshold any, tolerance string, compare floatComparator) Matcher {
+	if err := validateFloats(reflect.ValueOf(expected)); err != nil {
+		panic(err)
+	}
+
+	return approxMatcher{
+		expected:  expected,
+		tolerance: tolerance,
+		compare:   compare,
+		operator:  operator,
+		value:     []any{expected, threshold},
+	}
+}
+
+// InDelta matches if the absolute difference between the actual and the expectation is at most delta.
+//
+// The expectation could be a number, or a slice, an array or a map of numbers which is compared element-wise. NaN
+// only matches NaN and an infinity only matches the same infinity. The matcher panics if the expectation is invalid.
+func InDelta(expected any, delta float64) Matcher {
+	return newApproxMatcher(operatorInDelta, expected, delta, fmt.Sprintf("within %v of", delta), inDelta(delta))
+}
+
+// InEpsilon matches if the relative error between the actual and the expectation is at most epsilon. A zero
+// expectation only matches zero.
+//
+// See InDelta for the supported expectations.
+func InEpsilon(expected any, epsilon float64) Matcher {
+	return newApproxMatcher(operatorInEpsilon, expected, epsilon, fmt.Sprintf("within relative error %v of", epsilon), inEpsilon(epsilon))
+}
+
+// WithinULP matches if there are at most ulps representable floats between the actual and the expectation. The
+// distance is counted in float32 if both values are float32, otherwise in float64.
+//
+// See InDelta for the supported expectations.
+func WithinULP(expected any, ulps uint64) Matcher {
+	return newApproxMatcher(operatorWithinULP, expected, ulps, fmt.Sprintf("within %d ULPs of", ulps), withinULP(ulps))
+}
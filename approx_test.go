@@ -0,0 +1,404 @@
+package matcher_test
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.nhat.io/matcher/v3"
+)
+
+func TestApprox_Panic(t *testing.T) {
+	t.Parallel()
+
+	assert.Panics(t, func() {
+		matcher.InDelta("foobar", 0.1)
+	})
+
+	assert.Panics(t, func() {
+		matcher.InEpsilon([]any{1.0, "foobar"}, 0.1)
+	})
+
+	assert.Panics(t, func() {
+		matcher.WithinULP(map[string]any{"foo": nil}, 1)
+	})
+}
+
+func TestInDelta_Match(t *testing.T) {
+	t.Parallel()
+
+	value := 1.05
+
+	testCases := []struct {
+		scenario string
+		expected any
+		actual   any
+		result   bool
+	}{
+		{
+			scenario: "within delta",
+			expected: 1.0,
+			actual:   1.05,
+			result:   true,
+		},
+		{
+			scenario: "on delta",
+			expected: 1,
+			actual:   1.125,
+			result:   true,
+		},
+		{
+			scenario: "outside delta",
+			expected: 1.0,
+			actual:   1.2,
+		},
+		{
+			scenario: "int actual",
+			expected: 1.05,
+			actual:   1,
+			result:   true,
+		},
+		{
+			scenario: "float32 actual",
+			expected: 1.0,
+			actual:   float32(1.05),
+			result:   true,
+		},
+		{
+			scenario: "pointer actual",
+			expected: 1.0,
+			actual:   &value,
+			result:   true,
+		},
+		{
+			scenario: "not a number",
+			expected: 1.0,
+			actual:   "1.0",
+		},
+		{
+			scenario: "nil",
+			expected: 1.0,
+			actual:   nil,
+		},
+		{
+			scenario: "NaN and NaN",
+			expected: math.NaN(),
+			actual:   math.NaN(),
+			result:   true,
+		},
+		{
+			scenario: "NaN and number",
+			expected: math.NaN(),
+			actual:   1.0,
+		},
+		{
+			scenario: "number and NaN",
+			expected: 1.0,
+			actual:   math.NaN(),
+		},
+		{
+			scenario: "same infinity",
+			expected: math.Inf(1),
+			actual:   math.Inf(1),
+			result:   true,
+		},
+		{
+			scenario: "different infinity",
+			expected: math.Inf(1),
+			actual:   math.Inf(-1),
+		},
+		{
+			scenario: "infinity and number",
+			expected: math.Inf(1),
+			actual:   math.MaxFloat64,
+		},
+		{
+			scenario: "slice",
+			expected: []float64{1, 2},
+			actual:   []any{1.05, 2},
+			result:   true,
+		},
+		{
+			scenario: "slice mismatched",
+			expected: []float64{1, 2},
+			actual:   []float64{1, 2.5},
+		},
+		{
+			scenario: "slice len mismatched",
+			expected: []float64{1, 2},
+			actual:   []float64{1},
+		},
+		{
+			scenario: "array",
+			expected: [2]float64{1, 2},
+			actual:   []float64{1, 2.05},
+			result:   true,
+		},
+		{
+			scenario: "not a slice",
+			expected: []float64{1, 2},
+			actual:   1.0,
+		},
+		{
+			scenario: "map",
+			expected: map[string]float64{"a": 1},
+			actual:   map[string]any{"a": 0.95},
+			result:   true,
+		},
+		{
+			scenario: "map mismatched",
+			expected: map[string]float64{"a": 1},
+			actual:   map[string]any{"a": 2},
+		},
+		{
+			scenario: "map missing key",
+			expected: map[string]float64{"a": 1},
+			actual:   map[string]any{"b": 1},
+		},
+		{
+			scenario: "map len mismatched",
+			expected: map[string]float64{"a": 1},
+			actual:   map[string]any{"a": 1, "b": 1},
+		},
+		{
+			scenario: "map key type mismatched",
+			expected: map[string]float64{"a": 1},
+			actual:   map[int]float64{1: 1},
+		},
+		{
+			scenario: "nested",
+			expected: map[string][]float64{"a": {1, 2}},
+			actual:   map[string]any{"a": []any{1.01, 1.99}},
+			result:   true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.scenario, func(t *testing.T) {
+			t.Parallel()
+
+			result, err := matcher.InDelta(tc.expected, 0.125).Match(tc.actual)
+
+			assert.Equal(t, tc.result, result)
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestInEpsilon_Match(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		scenario string
+		expected any
+		actual   any
+		result   bool
+	}{
+		{
+			scenario: "within epsilon",
+			expected: 100.0,
+			actual:   101.0,
+			result:   true,
+		},
+		{
+			scenario: "within epsilon negative",
+			expected: -100.0,
+			actual:   -99,
+			result:   true,
+		},
+		{
+			scenario: "outside epsilon",
+			expected: 100.0,
+			actual:   102.0,
+		},
+		{
+			scenario: "zero and zero",
+			expected: 0,
+			actual:   0.0,
+			result:   true,
+		},
+		{
+			scenario: "zero and number",
+			expected: 0,
+			actual:   0.000001,
+		},
+		{
+			scenario: "NaN and NaN",
+			expected: math.NaN(),
+			actual:   math.NaN(),
+			result:   true,
+		},
+		{
+			scenario: "same infinity",
+			expected: math.Inf(-1),
+			actual:   math.Inf(-1),
+			result:   true,
+		},
+		{
+			scenario: "slice",
+			expected: []float64{100, 200},
+			actual:   []float64{101, 198},
+			result:   true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.scenario, func(t *testing.T) {
+			t.Parallel()
+
+			result, err := matcher.InEpsilon(tc.expected, 0.01).Match(tc.actual)
+
+			assert.Equal(t, tc.result, result)
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestWithinULP_Match(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		scenario string
+		expected any
+		actual   any
+		result   bool
+	}{
+		{
+			scenario: "equal",
+			expected: 1.0,
+			actual:   1.0,
+			result:   true,
+		},
+		{
+			scenario: "computed",
+			expected: 0.3,
+			actual:   0.1 + 0.2,
+			result:   true,
+		},
+		{
+			scenario: "next after",
+			expected: 1.0,
+			actual:   math.Nextafter(math.Nextafter(1, 2), 2),
+			result:   true,
+		},
+		{
+			scenario: "too far",
+			expected: 1.0,
+			actual:   math.Nextafter(math.Nextafter(math.Nextafter(1, 2), 2), 2),
+		},
+		{
+			scenario: "positive and negative zero",
+			expected: 0.0,
+			actual:   math.Copysign(0, -1),
+			result:   true,
+		},
+		{
+			scenario: "around zero",
+			expected: math.SmallestNonzeroFloat64,
+			actual:   -math.SmallestNonzeroFloat64,
+			result:   true,
+		},
+		{
+			scenario: "float32",
+			expected: float32(1),
+			actual:   math.Nextafter32(math.Nextafter32(1, 2), 2),
+			result:   true,
+		},
+		{
+			scenario: "float32 too far",
+			expected: float32(1),
+			actual:   math.Nextafter32(math.Nextafter32(math.Nextafter32(1, 2), 2), 2),
+		},
+		{
+			scenario: "NaN and NaN",
+			expected: math.NaN(),
+			actual:   math.NaN(),
+			result:   true,
+		},
+		{
+			scenario: "infinity and max",
+			expected: math.Inf(1),
+			actual:   math.MaxFloat64,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.scenario, func(t *testing.T) {
+			t.Parallel()
+
+			result, err := matcher.WithinULP(tc.expected, 2).Match(tc.actual)
+
+			assert.Equal(t, tc.result, result)
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestApprox_Expected(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "within 0.1 of 1.5", matcher.InDelta(1.5, 0.1).Expected())
+	assert.Equal(t, "within relative error 0.01 of [1 2]", matcher.InEpsilon([]float64{1, 2}, 0.01).Expected())
+	assert.Equal(t, "within 4 ULPs of 0.3", matcher.WithinULP(0.3, 4).Expected())
+	assert.Equal(t, "<within 0.1 of 1.5>", fmt.Sprintf("%#v", matcher.InDelta(1.5, 0.1)))
+}
+
+func TestApprox_Explain(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		scenario string
+		expected any
+		actual   any
+		result   string
+	}{
+		{
+			scenario: "number",
+			expected: 1.0,
+			actual:   2.0,
+			result:   `expected within 0.1 of 1, got float64(2)`,
+		},
+		{
+			scenario: "not a number",
+			expected: 1.0,
+			actual:   "2",
+			result:   `expected within 0.1 of 1, got string, not a number`,
+		},
+		{
+			scenario: "slice",
+			expected: []float64{1, 2, 3},
+			actual:   []float64{1, 2.5, 3.5},
+			result: `expected within 0.1 of [1 2 3], got []float64{1, 2.5, 3.5}
+  [1]: expected within 0.1 of 2, got float64(2.5)
+  [2]: expected within 0.1 of 3, got float64(3.5)`,
+		},
+		{
+			scenario: "slice len",
+			expected: []float64{1, 2, 3},
+			actual:   []float64{1},
+			result:   `expected within 0.1 of [1 2 3], got len 1`,
+		},
+		{
+			scenario: "map",
+			expected: map[string]float64{"a": 1, "b": 2},
+			actual:   map[string]float64{"a": 1.5, "c": 2},
+			result: `expected within 0.1 of map[a:1 b:2], got map[string]float64{"a":1.5, "c":2}
+  [a]: expected within 0.1 of 1, got float64(1.5)
+  [b]: expected within 0.1 of 2, key not found`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.scenario, func(t *testing.T) {
+			t.Parallel()
+
+			actual := matcher.Explain(matcher.InDelta(tc.expected, 0.1), tc.actual)
+
+			require.NotNil(t, actual)
+			assert.Equal(t, tc.result, actual.String())
+		})
+	}
+}
@@ -0,0 +1,61 @@
+package assert
+
+import (
+	"strings"
+
+	"github.com/stretchr/testify/assert"
+
+	"go.nhat.io/matcher/v3"
+)
+
+// TestingT is an interface wrapper around *testing.T.
+type TestingT interface {
+	Errorf(format string, args ...any)
+}
+
+type tHelper interface {
+	Helper()
+}
+
+// That asserts that the actual matches the matcher, using Match(). The failure message has the expectation, the
+// explanation of the mismatch and, if the matcher supports it, the diff of the expectation and the actual.
+//
+//	assert.That(t, `{"id": 42}`, matcher.JSON(`{"id": "<ignore-diff>"}`))
+func That(t TestingT, actual any, m matcher.Matcher, msgAndArgs ...any) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+
+	matched, err := m.Match(actual)
+	if matched && err == nil {
+		return true
+	}
+
+	return assert.Fail(t, failureMessage(m, actual, err), msgAndArgs...)
+}
+
+// failureMessage shows the diff for the matchers supporting it, otherwise the explanation of the mismatch. The error is
+// the one returned by Match().
+func failureMessage(m matcher.Matcher, actual any, err error) string {
+	if _, ok := m.(matcher.Differ); ok && err == nil {
+		if diff := matcher.Diff(m, actual); diff != "" {
+			return "Not matched: expected " + firstLine(m.Expected()) + "\n\nDiff:\n" + diff
+		}
+	}
+
+	mismatch := matcher.Explain(m, actual)
+	if mismatch == nil {
+		// The explanation disagrees with Match(), so only the result of Match() is shown.
+		mismatch = &matcher.Mismatch{Expected: m.Expected(), Actual: actual, Err: err}
+	}
+
+	return "Not matched: " + mismatch.String()
+}
+
+func firstLine(s string) string {
+	if i := strings.IndexByte(s, '\n'); i >= 0 {
+		return s[:i] + " ..."
+	}
+
+	return s
+}
@@ -0,0 +1,135 @@
+package assert_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"go.nhat.io/matcher/v3"
+	matcherassert "go.nhat.io/matcher/v3/assert"
+)
+
+// disagreeingMatcher is a matcher whose explanation disagrees with the result of Match().
+type disagreeingMatcher struct {
+	matched bool
+}
+
+func (m disagreeingMatcher) Match(any) (bool, error) {
+	return m.matched, nil
+}
+
+func (m disagreeingMatcher) Expected() string {
+	return "disagreeing"
+}
+
+func (m disagreeingMatcher) Explain(actual any) *matcher.Mismatch {
+	if !m.matched {
+		return nil
+	}
+
+	return &matcher.Mismatch{Expected: m.Expected(), Actual: actual}
+}
+
+type testingT struct {
+	errors []string
+}
+
+func (t *testingT) Errorf(format string, args ...any) {
+	t.errors = append(t.errors, fmt.Sprintf(format, args...))
+}
+
+func TestThat(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		scenario       string
+		matcher        matcher.Matcher
+		actual         any
+		msgAndArgs     []any
+		expectedResult bool
+		expectedErrors []string
+	}{
+		{
+			scenario:       "matched",
+			matcher:        matcher.Equal("foobar"),
+			actual:         "foobar",
+			expectedResult: true,
+		},
+		{
+			scenario: "explanation",
+			matcher:  matcher.HasPrefix("foo"),
+			actual:   "bar",
+			expectedErrors: []string{
+				`Not matched: expected has prefix "foo", got string("bar")`,
+			},
+		},
+		{
+			scenario: "diff",
+			matcher:  matcher.JSON(`{"id": 1, "name": "John"}`),
+			actual:   `{"id": 1, "name": "Jane"}`,
+			expectedErrors: []string{
+				`Not matched: expected {"id": 1, "name": "John"}`,
+				"Diff:",
+				"@@ -1,4 +1,4 @@ $.name",
+				`+  "name": "Jane"`,
+			},
+		},
+		{
+			scenario: "error",
+			matcher:  matcher.Len(1),
+			actual:   42,
+			expectedErrors: []string{
+				`Not matched: expected len is 1, error: len: value of type int does not have a length`,
+			},
+		},
+		{
+			scenario:       "explanation disagrees - matched",
+			matcher:        disagreeingMatcher{matched: true},
+			actual:         "foo",
+			expectedResult: true,
+		},
+		{
+			scenario: "explanation disagrees - mismatched",
+			matcher:  disagreeingMatcher{},
+			actual:   "foo",
+			expectedErrors: []string{
+				`Not matched: expected disagreeing, got string("foo")`,
+			},
+		},
+		{
+			scenario:   "message",
+			matcher:    matcher.Equal("foobar"),
+			actual:     "foo",
+			msgAndArgs: []any{"user %d", 42},
+			expectedErrors: []string{
+				"Not matched: expected foobar",
+				"user 42",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.scenario, func(t *testing.T) {
+			t.Parallel()
+
+			tt := &testingT{}
+
+			result := matcherassert.That(tt, tc.actual, tc.matcher, tc.msgAndArgs...)
+
+			assert.Equal(t, tc.expectedResult, result)
+
+			if tc.expectedErrors == nil {
+				assert.Empty(t, tt.errors)
+
+				return
+			}
+
+			assert.Len(t, tt.errors, 1)
+
+			for _, e := range tc.expectedErrors {
+				assert.Contains(t, tt.errors[0], e)
+			}
+		})
+	}
+}
@@ -0,0 +1,2 @@
+// Package assert provides functionalities for asserting values with matchers in tests.
+package assert
@@ -0,0 +1,288 @@
+package matcher
+
+import "fmt"
+
+// findElement finds the first element that matches.
+func findElement(m Matcher, elems []any) (int, error) {
+	for i, e := range elems {
+		if ok, err := m.Match(e); err != nil {
+			return -1, err
+		} else if ok {
+			return i, nil
+		}
+	}
+
+	return -1, nil
+}
+
+var (
+	_ Matcher   = (*elementsMatcher)(nil)
+	_ Explainer = (*elementsMatcher)(nil)
+)
+
+// elementsMatcher matches all the elements of a collection, in order or not.
+type elementsMatcher struct {
+	elements []element
+	ordered  bool
+}
+
+// Match determines if the actual is expected.
+func (m elementsMatcher) Match(actual any) (bool, error) {
+	elems, ok := elementsVal(actual)
+	if !ok || len(elems) != len(m.elements) {
+		return false, nil
+	}
+
+	if m.ordered {
+		for i, e := range m.elements {
+			if ok, err := e.matcher.Match(elems[i]); err != nil || !ok {
+				return false, err
+			}
+		}
+
+		return true, nil
+	}
+
+	unmatched, err := m.unmatched(elems)
+	if err != nil {
+		return false, err
+	}
+
+	return len(unmatched) == 0, nil
+}
+
+// Expected returns the expectation.
+func (m elementsMatcher) Expected() string {
+	if m.ordered {
+		return "consists of " + elementsExpected(m.elements)
+	}
+
+	return "contains exactly " + elementsExpected(m.elements) + " in any order"
+}
+
+// Explain explains why the actual is not expected.
+func (m elementsMatcher) Explain(actual any) *Mismatch {
+	elems, ok := elementsVal(actual)
+	if !ok {
+		return newMismatch(m, actual, fmt.Sprintf("got %T, not a collection", actual), nil)
+	}
+
+	if len(elems) != len(m.elements) {
+		return newMismatch(m, actual, fmt.Sprintf("got len %d", len(elems)), nil)
+	}
+
+	var children []*Mismatch
+
+	if m.ordered {
+		for i, e := range m.elements {
+			if c := Explain(e.matcher, elems[i]); c != nil {
+				c.Path = fmt.Sprintf("[%d]", i)
+				children = append(children, c)
+			}
+		}
+	} else {
+		unmatched, err := m.unmatched(elems)
+		if err != nil {
+			return newMismatch(m, actual, "", err)
+		}
+
+		for _, e := range unmatched {
+			children = append(children, &Mismatch{Expected: e.Expected(), Reason: "no matching element"})
+		}
+	}
+
+	if len(children) == 0 {
+		return nil
+	}
+
+	result := newMismatch(m, actual, "", nil)
+	result.Children = children
+
+	return result
+}
+
+func (m elementsMatcher) Format(s fmt.State, _ rune) {
+	_, _ = fmt.Fprintf(s, "<%s>", m.Expected()) //nolint: errcheck
+}
+
+// unmatched pairs the expectations with the elements, and returns the expectations that could not be paired.
+func (m elementsMatcher) unmatched(elems []any) ([]element, error) {
+	candidates := make([][]int, len(m.elements))
+
+	for i, e := range m.elements {
+		for j, el := range elems {
+			ok, err := e.matcher.Match(el)
+			if err != nil {
+				return nil, err
+			}
+
+			if ok {
+				candidates[i] = append(candidates[i], j)
+			}
+		}
+	}
+
+	pairs := pairElements(candidates, len(elems))
+
+	var unmatched []element
+
+	for i, j := range pairs {
+		if j < 0 {
+			unmatched = append(unmatched, m.elements[i])
+		}
+	}
+
+	return unmatched, nil
+}
+
+// pairElements finds a maximum matching between the expectations and the elements, using augmenting paths. The
+// candidates are the elements that each expectation matches. It returns the paired element of each expectation, or -1.
+func pairElements(candidates [][]int, numElements int) []int {
+	owners := make([]int, numElements)
+
+	for i := range owners {
+		owners[i] = -1
+	}
+
+	var augment func(i int, visited []bool) bool
+
+	augment = func(i int, visited []bool) bool {
+		for _, j := range candidates[i] {
+			if visited[j] {
+				continue
+			}
+
+			visited[j] = true
+
+			if owners[j] < 0 || augment(owners[j], visited) {
+				owners[j] = i
+
+				return true
+			}
+		}
+
+		return false
+	}
+
+	for i := range candidates {
+		augment(i, make([]bool, numElements))
+	}
+
+	pairs := make([]int, len(candidates))
+
+	for i := range pairs {
+		pairs[i] = -1
+	}
+
+	for j, i := range owners {
+		if i >= 0 {
+			pairs[i] = j
+		}
+	}
+
+	return pairs
+}
+
+var (
+	_ Matcher   = (*eachMatcher)(nil)
+	_ Explainer = (*eachMatcher)(nil)
+)
+
+// eachMatcher matches every element, or at least one element, of a collection.
+type eachMatcher struct {
+	matcher Matcher
+	any     bool
+}
+
+// Match determines if the actual is expected.
+func (m eachMatcher) Match(actual any) (bool, error) {
+	elems, ok := elementsVal(actual)
+	if !ok {
+		return false, nil
+	}
+
+	for _, e := range elems {
+		ok, err := m.matcher.Match(e)
+		if err != nil {
+			return false, err
+		}
+
+		if ok == m.any {
+			return m.any, nil
+		}
+	}
+
+	return !m.any, nil
+}
+
+// Expected returns the expectation.
+func (m eachMatcher) Expected() string {
+	if m.any {
+		return "any element " + m.matcher.Expected()
+	}
+
+	return "each element " + m.matcher.Expected()
+}
+
+// Explain explains why the actual is not expected.
+func (m eachMatcher) Explain(actual any) *Mismatch {
+	elems, ok := elementsVal(actual)
+	if !ok {
+		return newMismatch(m, actual, fmt.Sprintf("got %T, not a collection", actual), nil)
+	}
+
+	if m.any {
+		return explain(m, actual, "")
+	}
+
+	var children []*Mismatch
+
+	for i, e := range elems {
+		if c := Explain(m.matcher, e); c != nil {
+			c.Path = fmt.Sprintf("[%d]", i)
+			children = append(children, c)
+		}
+	}
+
+	if len(children) == 0 {
+		return nil
+	}
+
+	result := newMismatch(m, actual, "", nil)
+	result.Children = children
+
+	return result
+}
+
+func (m eachMatcher) Format(s fmt.State, _ rune) {
+	_, _ = fmt.Fprintf(s, "<%s>", m.Expected()) //nolint: errcheck
+}
+
+// ElementsMatch matches if the elements of the actual match the expectations, in any order. Each element must match a
+// distinct expectation.
+//
+// See Contains for the supported actual values.
+func ElementsMatch(expected ...any) Matcher {
+	return elementsMatcher{elements: makeElements(expected...)}
+}
+
+// ConsistOf matches if the elements of the actual match the expectations, in order.
+//
+// See Contains for the supported actual values.
+func ConsistOf(expected ...any) Matcher {
+	return elementsMatcher{elements: makeElements(expected...), ordered: true}
+}
+
+// Each matches if every element of the actual matches the expectation. An empty collection always matches.
+//
+// See Contains for the supported actual values.
+func Each(expected any) Matcher {
+	return eachMatcher{matcher: makeNestedMatcher(expected)}
+}
+
+// AnyElement matches if at least one element of the actual matches the expectation.
+//
+// See Contains for the supported actual values.
+func AnyElement(expected any) Matcher {
+	return eachMatcher{matcher: makeNestedMatcher(expected), any: true}
+}
@@ -0,0 +1,471 @@
+package matcher_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.nhat.io/matcher/v3"
+)
+
+func TestCollection_Match(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		scenario string
+		matcher  matcher.Matcher
+		actual   any
+		expected bool
+	}{
+		{
+			scenario: "contains - slice",
+			matcher:  matcher.Contains("foo"),
+			actual:   []string{"bar", "foo"},
+			expected: true,
+		},
+		{
+			scenario: "contains - array",
+			matcher:  matcher.Contains(2),
+			actual:   [3]int{1, 2, 3},
+			expected: true,
+		},
+		{
+			scenario: "contains - slice pointer",
+			matcher:  matcher.Contains(2),
+			actual:   &[]int{1, 2, 3},
+			expected: true,
+		},
+		{
+			scenario: "contains - any slice",
+			matcher:  matcher.Contains("foo"),
+			actual:   []any{42, "foo"},
+			expected: true,
+		},
+		{
+			scenario: "contains - matcher",
+			matcher:  matcher.Contains(matcher.Regex("^ba")),
+			actual:   []string{"foo", "bar"},
+			expected: true,
+		},
+		{
+			scenario: "contains - nested matcher",
+			matcher:  matcher.Contains(matcher.Len(2)),
+			actual:   [][]int{{1}, {1, 2}},
+			expected: true,
+		},
+		{
+			scenario: "contains - substring",
+			matcher:  matcher.Contains("oba"),
+			actual:   "foobar",
+			expected: true,
+		},
+		{
+			scenario: "contains - not found",
+			matcher:  matcher.Contains("baz"),
+			actual:   []string{"foo", "bar"},
+		},
+		{
+			scenario: "contains - not a collection",
+			matcher:  matcher.Contains(42),
+			actual:   42,
+		},
+		{
+			scenario: "contains - matcher in string",
+			matcher:  matcher.Contains(matcher.Regex("^f")),
+			actual:   "foobar",
+		},
+		{
+			scenario: "contains - nil",
+			matcher:  matcher.Contains(42),
+			actual:   nil,
+		},
+		{
+			scenario: "contains all",
+			matcher:  matcher.ContainsAll(1, 3),
+			actual:   []int{1, 2, 3},
+			expected: true,
+		},
+		{
+			scenario: "contains all - missing",
+			matcher:  matcher.ContainsAll(1, 4),
+			actual:   []int{1, 2, 3},
+		},
+		{
+			scenario: "contains all - empty",
+			matcher:  matcher.ContainsAll(),
+			actual:   []int{},
+			expected: true,
+		},
+		{
+			scenario: "contains any",
+			matcher:  matcher.ContainsAny(4, 3),
+			actual:   []int{1, 2, 3},
+			expected: true,
+		},
+		{
+			scenario: "contains any - missing",
+			matcher:  matcher.ContainsAny(4, 5),
+			actual:   []int{1, 2, 3},
+		},
+		{
+			scenario: "contains any - empty",
+			matcher:  matcher.ContainsAny(),
+			actual:   []int{1},
+		},
+		{
+			scenario: "elements match",
+			matcher:  matcher.ElementsMatch(3, 1, 2),
+			actual:   []int{1, 2, 3},
+			expected: true,
+		},
+		{
+			scenario: "elements match - duplicates",
+			matcher:  matcher.ElementsMatch(1, 1, 2),
+			actual:   []int{1, 2, 2},
+		},
+		{
+			scenario: "elements match - overlapping matchers",
+			matcher:  matcher.ElementsMatch(matcher.Regex("^f"), "foo"),
+			actual:   []string{"foo", "far"},
+			expected: true,
+		},
+		{
+			scenario: "elements match - len",
+			matcher:  matcher.ElementsMatch(1, 2),
+			actual:   []int{1, 2, 3},
+		},
+		{
+			scenario: "elements match - not a collection",
+			matcher:  matcher.ElementsMatch(1),
+			actual:   1,
+		},
+		{
+			scenario: "consist of",
+			matcher:  matcher.ConsistOf(1, matcher.GreaterThan(1)),
+			actual:   []int{1, 2},
+			expected: true,
+		},
+		{
+			scenario: "consist of - order",
+			matcher:  matcher.ConsistOf(2, 1),
+			actual:   []int{1, 2},
+		},
+		{
+			scenario: "consist of - len",
+			matcher:  matcher.ConsistOf(1),
+			actual:   []int{1, 2},
+		},
+		{
+			scenario: "each",
+			matcher:  matcher.Each(matcher.Regex("^f")),
+			actual:   []string{"foo", "far"},
+			expected: true,
+		},
+		{
+			scenario: "each - mismatched",
+			matcher:  matcher.Each(matcher.Regex("^f")),
+			actual:   []string{"foo", "bar"},
+		},
+		{
+			scenario: "each - empty",
+			matcher:  matcher.Each(matcher.Regex("^f")),
+			actual:   []string{},
+			expected: true,
+		},
+		{
+			scenario: "each - not a collection",
+			matcher:  matcher.Each(matcher.Regex("^f")),
+			actual:   "foo",
+		},
+		{
+			scenario: "any element",
+			matcher:  matcher.AnyElement(matcher.Len(2)),
+			actual:   []string{"foo", "ba"},
+			expected: true,
+		},
+		{
+			scenario: "any element - mismatched",
+			matcher:  matcher.AnyElement(matcher.Len(2)),
+			actual:   []string{"foo", "bar"},
+		},
+		{
+			scenario: "any element - empty",
+			matcher:  matcher.AnyElement(matcher.Len(2)),
+			actual:   []string{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.scenario, func(t *testing.T) {
+			t.Parallel()
+
+			result, err := tc.matcher.Match(tc.actual)
+
+			assert.Equal(t, tc.expected, result)
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestCollection_Match_Chan(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		scenario string
+		matcher  matcher.Matcher
+	}{
+		{
+			scenario: "contains",
+			matcher:  matcher.Contains(1),
+		},
+		{
+			scenario: "elements match",
+			matcher:  matcher.ElementsMatch(2, 1),
+		},
+		{
+			scenario: "each",
+			matcher:  matcher.Each(matcher.GreaterThan(0)),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.scenario, func(t *testing.T) {
+			t.Parallel()
+
+			ch := make(chan int, 3)
+			ch <- 1
+			ch <- 2
+
+			close(ch)
+
+			result, err := tc.matcher.Match(ch)
+
+			assert.False(t, result)
+			require.NoError(t, err)
+
+			actual := matcher.Explain(tc.matcher, ch)
+
+			require.NotNil(t, actual)
+			assert.Contains(t, actual.Reason, "got chan int, not a")
+
+			assert.Len(t, ch, 2)
+			assert.Equal(t, 1, <-ch)
+			assert.Equal(t, 2, <-ch)
+		})
+	}
+}
+
+func TestCollection_Match_Error(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		scenario string
+		matcher  matcher.Matcher
+	}{
+		{
+			scenario: "contains",
+			matcher:  matcher.Contains(matcher.Len(1)),
+		},
+		{
+			scenario: "elements match",
+			matcher:  matcher.ElementsMatch(matcher.Len(1)),
+		},
+		{
+			scenario: "consist of",
+			matcher:  matcher.ConsistOf(matcher.Len(1)),
+		},
+		{
+			scenario: "each",
+			matcher:  matcher.Each(matcher.Len(1)),
+		},
+		{
+			scenario: "any element",
+			matcher:  matcher.AnyElement(matcher.Len(1)),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.scenario, func(t *testing.T) {
+			t.Parallel()
+
+			result, err := tc.matcher.Match([]int{1})
+
+			assert.False(t, result)
+			require.EqualError(t, err, `len: value of type int does not have a length`)
+		})
+	}
+}
+
+func TestCollection_Expected(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		scenario string
+		matcher  matcher.Matcher
+		expected string
+	}{
+		{
+			scenario: "contains",
+			matcher:  matcher.Contains(42),
+			expected: `contains 42`,
+		},
+		{
+			scenario: "contains matcher",
+			matcher:  matcher.Contains(matcher.Or(1, 2)),
+			expected: `contains (1 or 2)`,
+		},
+		{
+			scenario: "contains all",
+			matcher:  matcher.ContainsAll("foo", matcher.Len(2)),
+			expected: `contains all of ["foo", len is 2]`,
+		},
+		{
+			scenario: "contains any",
+			matcher:  matcher.ContainsAny(1, 2),
+			expected: `contains any of [1, 2]`,
+		},
+		{
+			scenario: "elements match",
+			matcher:  matcher.ElementsMatch(1, "foo"),
+			expected: `contains exactly [1, "foo"] in any order`,
+		},
+		{
+			scenario: "consist of",
+			matcher:  matcher.ConsistOf(1, "foo"),
+			expected: `consists of [1, "foo"]`,
+		},
+		{
+			scenario: "each",
+			matcher:  matcher.Each(matcher.GreaterThan(1)),
+			expected: `each element > 1`,
+		},
+		{
+			scenario: "any element",
+			matcher:  matcher.AnyElement(matcher.GreaterThan(1)),
+			expected: `any element > 1`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.scenario, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tc.expected, tc.matcher.Expected())
+		})
+	}
+}
+
+func TestCollectionMatcher_Format(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "<contains 42>", fmt.Sprintf("%#v", matcher.Contains(42)))
+	assert.Equal(t, "<consists of [1, 2]>", fmt.Sprintf("%#v", matcher.ConsistOf(1, 2)))
+	assert.Equal(t, "<each element > 1>", fmt.Sprintf("%#v", matcher.Each(matcher.GreaterThan(1))))
+}
+
+func TestCollection_Explain(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		scenario string
+		matcher  matcher.Matcher
+		actual   any
+		expected string
+	}{
+		{
+			scenario: "contains",
+			matcher:  matcher.Contains(4),
+			actual:   []int{1, 2},
+			expected: `expected contains 4, got []int{1, 2}`,
+		},
+		{
+			scenario: "contains not a collection",
+			matcher:  matcher.Contains(4),
+			actual:   4,
+			expected: `expected contains 4, got int, not a string or a collection`,
+		},
+		{
+			scenario: "contains all",
+			matcher:  matcher.ContainsAll("foo", "bar", "baz"),
+			actual:   "foo",
+			expected: `expected contains all of ["foo", "bar", "baz"], got string("foo")
+  expected "bar", not found
+  expected "baz", not found`,
+		},
+		{
+			scenario: "contains error",
+			matcher:  matcher.Contains(matcher.Len(1)),
+			actual:   []int{1},
+			expected: `expected contains len is 1, error: len: value of type int does not have a length`,
+		},
+		{
+			scenario: "elements match",
+			matcher:  matcher.ElementsMatch(1, 1, 2),
+			actual:   []int{1, 2, 2},
+			expected: `expected contains exactly [1, 1, 2] in any order, got []int{1, 2, 2}
+  expected 1, no matching element`,
+		},
+		{
+			scenario: "elements match len",
+			matcher:  matcher.ElementsMatch(1, 2),
+			actual:   []int{1},
+			expected: `expected contains exactly [1, 2] in any order, got len 1`,
+		},
+		{
+			scenario: "consist of",
+			matcher:  matcher.ConsistOf(1, matcher.Regex("^b")),
+			actual:   []any{2, "bar"},
+			expected: `expected consists of [1, ^b], got []interface {}{2, "bar"}
+  [0]: expected 1, got int(2)`,
+		},
+		{
+			scenario: "consist of not a collection",
+			matcher:  matcher.ConsistOf(1),
+			actual:   1,
+			expected: `expected consists of [1], got int, not a collection`,
+		},
+		{
+			scenario: "each",
+			matcher:  matcher.Each(matcher.Len(3)),
+			actual:   []string{"foo", "ba", "baz", "b"},
+			expected: `expected each element len is 3, got []string{"foo", "ba", "baz", "b"}
+  [1]: expected len is 3, got len 2
+  [3]: expected len is 3, got len 1`,
+		},
+		{
+			scenario: "any element",
+			matcher:  matcher.AnyElement(matcher.Len(3)),
+			actual:   []string{"ba"},
+			expected: `expected any element len is 3, got []string{"ba"}`,
+		},
+		{
+			scenario: "any element not a collection",
+			matcher:  matcher.AnyElement(matcher.Len(3)),
+			actual:   nil,
+			expected: `expected any element len is 3, got <nil>, not a collection`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.scenario, func(t *testing.T) {
+			t.Parallel()
+
+			actual := matcher.Explain(tc.matcher, tc.actual)
+
+			require.NotNil(t, actual)
+			assert.Equal(t, tc.expected, actual.String())
+		})
+	}
+}
+
+func TestCollection_Explain_Matched(t *testing.T) {
+	t.Parallel()
+
+	assert.Nil(t, matcher.Explain(matcher.Contains(1), []int{1}))
+	assert.Nil(t, matcher.Explain(matcher.ElementsMatch(2, 1), []int{1, 2}))
+	assert.Nil(t, matcher.Explain(matcher.ConsistOf(1, 2), []int{1, 2}))
+	assert.Nil(t, matcher.Explain(matcher.Each(1), []int{1, 1}))
+	assert.Nil(t, matcher.Explain(matcher.AnyElement(1), []int{2, 1}))
+}
@@ -0,0 +1,154 @@
+package matcher
+
+import (
+	"cmp"
+	"fmt"
+)
+
+const (
+	compareOperatorGreaterThan    = ">"
+	compareOperatorGreaterOrEqual = ">="
+	compareOperatorLessThan       = "<"
+	compareOperatorLessOrEqual    = "<="
+)
+
+type compareOperator string
+
+func (o compareOperator) accept(c int) bool {
+	switch o {
+	case compareOperatorGreaterThan:
+		return c > 0
+
+	case compareOperatorGreaterOrEqual:
+		return c >= 0
+
+	case compareOperatorLessThan:
+		return c < 0
+
+	case compareOperatorLessOrEqual:
+		return c <= 0
+	}
+
+	return false
+}
+
+var (
+	_ Matcher   = (*compareMatcher)(nil)
+	_ Explainer = (*compareMatcher)(nil)
+)
+
+// compareMatcher matches by comparing the actual with the expectation.
+type compareMatcher struct {
+	expected any
+	operator compareOperator
+}
+
+// Match determines if the actual is expected.
+func (m compareMatcher) Match(actual any) (bool, error) {
+	c, ok := compareVal(actual, m.expected)
+	if !ok {
+		return false, nil
+	}
+
+	return m.operator.accept(c), nil
+}
+
+// Expected returns the expectation.
+func (m compareMatcher) Expected() string {
+	return fmt.Sprintf("%s %v", m.operator, m.expected)
+}
+
+// Explain explains why the actual is not expected.
+func (m compareMatcher) Explain(actual any) *Mismatch {
+	if _, ok := compareVal(actual, m.expected); !ok {
+		return newMismatch(m, actual, fmt.Sprintf("got %T, not comparable with %T", actual, m.expected), nil)
+	}
+
+	return explain(m, actual, "")
+}
+
+func (m compareMatcher) Format(s fmt.State, _ rune) {
+	_, _ = fmt.Fprintf(s, "<%s>", m.Expected()) //nolint: errcheck
+}
+
+var (
+	_ Matcher   = (*betweenMatcher)(nil)
+	_ Explainer = (*betweenMatcher)(nil)
+)
+
+// betweenMatcher matches if the actual is in a closed interval.
+type betweenMatcher struct {
+	lower any
+	upper any
+}
+
+// Match determines if the actual is expected.
+func (m betweenMatcher) Match(actual any) (bool, error) {
+	lc, ok := compareVal(actual, m.lower)
+	if !ok {
+		return false, nil
+	}
+
+	uc, ok := compareVal(actual, m.upper)
+	if !ok {
+		return false, nil
+	}
+
+	return lc >= 0 && uc <= 0, nil
+}
+
+// Expected returns the expectation.
+func (m betweenMatcher) Expected() string {
+	return fmt.Sprintf("between %v and %v", m.lower, m.upper)
+}
+
+// Explain explains why the actual is not expected.
+func (m betweenMatcher) Explain(actual any) *Mismatch {
+	if _, ok := compareVal(actual, m.lower); !ok {
+		return newMismatch(m, actual, fmt.Sprintf("got %T, not comparable with %T", actual, m.lower), nil)
+	}
+
+	return explain(m, actual, "")
+}
+
+func (m betweenMatcher) Format(s fmt.State, _ rune) {
+	_, _ = fmt.Fprintf(s, "<%s>", m.Expected()) //nolint: errcheck
+}
+
+// GreaterThan matches if the actual is greater than the expectation.
+//
+// Numbers of different kinds are converted before being compared, so an int expectation matches an int64 or a float64
+// actual, like the ones decoded from json. Durations are compared like numbers.
+//
+// Times are not cmp.Ordered, so they are matched by Before, After and TimeBetween instead.
+func GreaterThan[T cmp.Ordered](expected T) Matcher {
+	return compareMatcher{expected: expected, operator: compareOperatorGreaterThan}
+}
+
+// GreaterOrEqual matches if the actual is greater than or equal to the expectation.
+//
+// See GreaterThan for the conversion of numbers and the matchers of times.
+func GreaterOrEqual[T cmp.Ordered](expected T) Matcher {
+	return compareMatcher{expected: expected, operator: compareOperatorGreaterOrEqual}
+}
+
+// LessThan matches if the actual is less than the expectation.
+//
+// See GreaterThan for the conversion of numbers and the matchers of times.
+func LessThan[T cmp.Ordered](expected T) Matcher {
+	return compareMatcher{expected: expected, operator: compareOperatorLessThan}
+}
+
+// LessOrEqual matches if the actual is less than or equal to the expectation.
+//
+// See GreaterThan for the conversion of numbers and the matchers of times.
+func LessOrEqual[T cmp.Ordered](expected T) Matcher {
+	return compareMatcher{expected: expected, operator: compareOperatorLessOrEqual}
+}
+
+// Between matches if the actual is between lower and upper, inclusively.
+//
+// See GreaterThan for the conversion of numbers and the matchers of times.
+func Between[T cmp.Ordered](lower, upper T) Matcher {
+	return betweenMatcher{lower: lower, upper: upper}
+}
@@ -0,0 +1,282 @@
+package matcher_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.nhat.io/matcher/v3"
+)
+
+func TestCompare_Match(t *testing.T) {
+	t.Parallel()
+
+	five := 5
+
+	testCases := []struct {
+		scenario string
+		matcher  matcher.Matcher
+		actual   any
+		expected bool
+	}{
+		{
+			scenario: "greater than - greater",
+			matcher:  matcher.GreaterThan(5),
+			actual:   6,
+			expected: true,
+		},
+		{
+			scenario: "greater than - equal",
+			matcher:  matcher.GreaterThan(5),
+			actual:   5,
+		},
+		{
+			scenario: "greater than - int64",
+			matcher:  matcher.GreaterThan(5),
+			actual:   int64(6),
+			expected: true,
+		},
+		{
+			scenario: "greater than - float64",
+			matcher:  matcher.GreaterThan(5),
+			actual:   5.5,
+			expected: true,
+		},
+		{
+			scenario: "greater than - uint",
+			matcher:  matcher.GreaterThan(-1),
+			actual:   uint(0),
+			expected: true,
+		},
+		{
+			scenario: "greater than - json number",
+			matcher:  matcher.GreaterThan(5),
+			actual:   json.Number("6"),
+			expected: true,
+		},
+		{
+			scenario: "greater than - pointer",
+			matcher:  matcher.GreaterThan(4),
+			actual:   &five,
+			expected: true,
+		},
+		{
+			scenario: "greater than - nil",
+			matcher:  matcher.GreaterThan(4),
+			actual:   nil,
+		},
+		{
+			scenario: "greater than - not comparable",
+			matcher:  matcher.GreaterThan(4),
+			actual:   "foobar",
+		},
+		{
+			scenario: "greater than - NaN",
+			matcher:  matcher.GreaterThan(4),
+			actual:   math.NaN(),
+		},
+		{
+			scenario: "greater or equal - equal",
+			matcher:  matcher.GreaterOrEqual(5),
+			actual:   5.0,
+			expected: true,
+		},
+		{
+			scenario: "greater or equal - less",
+			matcher:  matcher.GreaterOrEqual(5),
+			actual:   4,
+		},
+		{
+			scenario: "less than - less",
+			matcher:  matcher.LessThan(uint8(5)),
+			actual:   -1,
+			expected: true,
+		},
+		{
+			scenario: "less than - equal",
+			matcher:  matcher.LessThan(5),
+			actual:   5,
+		},
+		{
+			scenario: "less or equal - equal",
+			matcher:  matcher.LessOrEqual(5),
+			actual:   uint64(5),
+			expected: true,
+		},
+		{
+			scenario: "less or equal - greater",
+			matcher:  matcher.LessOrEqual(5),
+			actual:   5.1,
+		},
+		{
+			scenario: "string",
+			matcher:  matcher.GreaterThan("b"),
+			actual:   "c",
+			expected: true,
+		},
+		{
+			scenario: "[]byte",
+			matcher:  matcher.LessThan("b"),
+			actual:   []byte("a"),
+			expected: true,
+		},
+		{
+			scenario: "duration",
+			matcher:  matcher.LessThan(time.Second),
+			actual:   time.Millisecond,
+			expected: true,
+		},
+		{
+			scenario: "between - lower",
+			matcher:  matcher.Between(1, 3),
+			actual:   1,
+			expected: true,
+		},
+		{
+			scenario: "between - upper",
+			matcher:  matcher.Between(1, 3),
+			actual:   3.0,
+			expected: true,
+		},
+		{
+			scenario: "between - below",
+			matcher:  matcher.Between(1, 3),
+			actual:   0,
+		},
+		{
+			scenario: "between - above",
+			matcher:  matcher.Between(1, 3),
+			actual:   int8(4),
+		},
+		{
+			scenario: "between - not comparable",
+			matcher:  matcher.Between(1, 3),
+			actual:   "2",
+		},
+		{
+			scenario: "logical",
+			matcher:  matcher.And(matcher.GreaterThan(1), matcher.Or(matcher.LessThan(3), 5)),
+			actual:   5,
+			expected: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.scenario, func(t *testing.T) {
+			t.Parallel()
+
+			result, err := tc.matcher.Match(tc.actual)
+
+			assert.Equal(t, tc.expected, result)
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestCompare_Expected(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		scenario string
+		matcher  matcher.Matcher
+		expected string
+	}{
+		{
+			scenario: "greater than",
+			matcher:  matcher.GreaterThan(5),
+			expected: "> 5",
+		},
+		{
+			scenario: "greater or equal",
+			matcher:  matcher.GreaterOrEqual(5.5),
+			expected: ">= 5.5",
+		},
+		{
+			scenario: "less than",
+			matcher:  matcher.LessThan(time.Second),
+			expected: "< 1s",
+		},
+		{
+			scenario: "less or equal",
+			matcher:  matcher.LessOrEqual("foo"),
+			expected: "<= foo",
+		},
+		{
+			scenario: "between",
+			matcher:  matcher.Between(1, 3),
+			expected: "between 1 and 3",
+		},
+		{
+			scenario: "logical",
+			matcher:  matcher.Or(matcher.LessThan(1), matcher.GreaterThan(3)),
+			expected: "< 1 or > 3",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.scenario, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tc.expected, tc.matcher.Expected())
+		})
+	}
+}
+
+func TestCompareMatcher_Format(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "<> 5>", fmt.Sprintf("%#v", matcher.GreaterThan(5)))
+	assert.Equal(t, "<between 1 and 3>", fmt.Sprintf("%#v", matcher.Between(1, 3)))
+}
+
+func TestCompare_Explain(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		scenario string
+		matcher  matcher.Matcher
+		actual   any
+		expected string
+	}{
+		{
+			scenario: "mismatched",
+			matcher:  matcher.GreaterThan(5),
+			actual:   4,
+			expected: `expected > 5, got int(4)`,
+		},
+		{
+			scenario: "not comparable",
+			matcher:  matcher.GreaterThan(5),
+			actual:   "foo",
+			expected: `expected > 5, got string, not comparable with int`,
+		},
+		{
+			scenario: "between",
+			matcher:  matcher.Between(1, 3),
+			actual:   4,
+			expected: `expected between 1 and 3, got int(4)`,
+		},
+		{
+			scenario: "between not comparable",
+			matcher:  matcher.Between(1, 3),
+			actual:   nil,
+			expected: `expected between 1 and 3, got <nil>, not comparable with int`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.scenario, func(t *testing.T) {
+			t.Parallel()
+
+			actual := matcher.Explain(tc.matcher, tc.actual)
+
+			require.NotNil(t, actual)
+			assert.Equal(t, tc.expected, actual.String())
+		})
+	}
+}
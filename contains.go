@@ -0,0 +1,197 @@
+package matcher
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+const (
+	containsQuantifierOne = ""
+	containsQuantifierAll = "all of"
+	containsQuantifierAny = "any of"
+)
+
+type containsQuantifier string
+
+// element is an expectation of an element of a collection.
+type element struct {
+	value   any
+	matcher Matcher
+}
+
+// Expected returns the expectation. Strings are quoted to distinguish them from the other matchers.
+func (e element) Expected() string {
+	if v := reflect.ValueOf(e.value); v.Kind() == reflect.String {
+		return fmt.Sprintf("%q", v.String())
+	}
+
+	return e.matcher.Expected()
+}
+
+func makeElements(v ...any) []element {
+	elements := make([]element, len(v))
+
+	for i, e := range v {
+		elements[i] = element{value: e, matcher: makeNestedMatcher(e)}
+	}
+
+	return elements
+}
+
+func elementsExpected(elements []element) string {
+	expected := make([]string, len(elements))
+
+	for i, e := range elements {
+		expected[i] = e.Expected()
+	}
+
+	return "[" + strings.Join(expected, ", ") + "]"
+}
+
+// substrings returns the expectations as strings if all of them are strings.
+func substrings(elements []element) ([]string, bool) {
+	result := make([]string, len(elements))
+
+	for i, e := range elements {
+		v := reflect.ValueOf(e.value)
+		if v.Kind() != reflect.String {
+			return nil, false
+		}
+
+		result[i] = v.String()
+	}
+
+	return result, true
+}
+
+var (
+	_ Matcher   = (*containsMatcher)(nil)
+	_ Explainer = (*containsMatcher)(nil)
+)
+
+// containsMatcher checks whether a string contains substrings or a collection contains elements.
+type containsMatcher struct {
+	elements   []element
+	quantifier containsQuantifier
+}
+
+// Match determines if the actual is expected.
+func (m containsMatcher) Match(actual any) (bool, error) {
+	missing, ok, err := m.missing(actual)
+	if !ok || err != nil {
+		return false, err
+	}
+
+	return m.found(missing), nil
+}
+
+// Expected returns the expectation.
+func (m containsMatcher) Expected() string {
+	if m.quantifier == containsQuantifierOne {
+		return "contains " + m.elements[0].Expected()
+	}
+
+	return fmt.Sprintf("contains %s %s", m.quantifier, elementsExpected(m.elements))
+}
+
+// Explain explains why the actual is not expected.
+func (m containsMatcher) Explain(actual any) *Mismatch {
+	missing, ok, err := m.missing(actual)
+	if !ok {
+		return newMismatch(m, actual, fmt.Sprintf("got %T, not a string or a collection", actual), nil)
+	}
+
+	if err != nil {
+		return newMismatch(m, actual, "", err)
+	}
+
+	if m.found(missing) {
+		return nil
+	}
+
+	result := newMismatch(m, actual, "", nil)
+
+	if m.quantifier != containsQuantifierOne {
+		for _, e := range missing {
+			result.Children = append(result.Children, &Mismatch{Expected: e.Expected(), Reason: "not found"})
+		}
+	}
+
+	return result
+}
+
+func (m containsMatcher) Format(s fmt.State, _ rune) {
+	_, _ = fmt.Fprintf(s, "<%s>", m.Expected()) //nolint: errcheck
+}
+
+// found determines if the expectations are found, according to the quantifier.
+func (m containsMatcher) found(missing []element) bool {
+	if m.quantifier == containsQuantifierAny {
+		return len(missing) < len(m.elements)
+	}
+
+	return len(missing) == 0
+}
+
+// missing returns the expectations that are not found in the actual. The ok flag is false if the actual is neither a
+// string nor a collection.
+func (m containsMatcher) missing(actual any) (_ []element, ok bool, _ error) {
+	if s := textVal(actual); s != nil {
+		if substrs, ok := substrings(m.elements); ok {
+			var missing []element
+
+			for i, substr := range substrs {
+				if !strings.Contains(*s, substr) {
+					missing = append(missing, m.elements[i])
+				}
+			}
+
+			return missing, true, nil
+		}
+	}
+
+	elems, ok := elementsVal(actual)
+	if !ok {
+		return nil, false, nil
+	}
+
+	var missing []element
+
+	for _, e := range m.elements {
+		i, err := findElement(e.matcher, elems)
+		if err != nil {
+			return nil, true, err
+		}
+
+		if i < 0 {
+			missing = append(missing, e)
+		}
+	}
+
+	return missing, true, nil
+}
+
+// Contains matches if the actual contains the expectation.
+//
+// If the expectation is a string and the actual is a string, a []byte, a fmt.Stringer or an error, it matches the
+// substring. Otherwise, the actual must be an array, a slice, or a pointer to them, and one of its elements must match
+// the expectation. The expectation is converted using Match(). Channels are not supported, because their elements could
+// not be read without receiving them; collect them into a slice first.
+func Contains(expected any) Matcher {
+	return containsMatcher{elements: makeElements(expected), quantifier: containsQuantifierOne}
+}
+
+// ContainsAll matches if the actual contains all the expectations.
+//
+// See Contains for the supported expectations and actual values.
+func ContainsAll(expected ...any) Matcher {
+	return containsMatcher{elements: makeElements(expected...), quantifier: containsQuantifierAll}
+}
+
+// ContainsAny matches if the actual contains at least one of the expectations.
+//
+// See Contains for the supported expectations and actual values.
+func ContainsAny(expected ...any) Matcher {
+	return containsMatcher{elements: makeElements(expected...), quantifier: containsQuantifierAny}
+}
@@ -0,0 +1,285 @@
+package matcher
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/davecgh/go-spew/spew"
+	"github.com/pmezard/go-difflib/difflib"
+)
+
+// diffContext is the number of unchanged lines around the changes.
+const diffContext = 3
+
+var diffSpewConfig = spew.ConfigState{
+	Indent:                  " ",
+	DisablePointerAddresses: true,
+	DisableCapacities:       true,
+	SortKeys:                true,
+	DisableMethods:          true,
+	MaxDepth:                10,
+}
+
+// Differ shows the differences between the expectation and the actual.
+type Differ interface {
+	// Diff returns an empty string if the actual matches the expectation, otherwise a unified diff of the expectation
+	// and the actual. An error of the matcher is not a match, so the diff is not empty, unless the expectation and the
+	// actual have the same text.
+	Diff(actual any) string
+}
+
+// Diff returns a unified diff of the pretty-printed expectation and actual, or an empty string if the actual matches.
+//
+// If the matcher does not implement Differ, the diff is between the result of Expected() and the actual.
+func Diff(m Matcher, actual any) string {
+	if d, ok := m.(Differ); ok {
+		return d.Diff(actual)
+	}
+
+	if ok, err := m.Match(actual); ok && err == nil {
+		return ""
+	}
+
+	return unifiedDiff(splitLines(m.Expected()), splitLines(prettyVal(actual)), nil)
+}
+
+// prettyVal prints the value in multiple lines. Strings are printed as is.
+func prettyVal(v any) string {
+	if s, ok := v.(string); ok {
+		return s
+	}
+
+	return diffSpewConfig.Sdump(v)
+}
+
+func splitLines(s string) []string {
+	return strings.Split(strings.TrimSuffix(s, "\n"), "\n")
+}
+
+// unifiedDiff returns the unified diff of the lines. The annotate function, if any, gets the first change of each hunk
+// and returns the text to append to the hunk header.
+func unifiedDiff(expected, actual []string, annotate func(c difflib.OpCode) string) string {
+	groups := difflib.NewMatcher(expected, actual).GetGroupedOpCodes(diffContext)
+	if len(groups) == 0 {
+		return ""
+	}
+
+	var sb strings.Builder
+
+	sb.WriteString("--- Expected\n+++ Actual\n")
+
+	for _, g := range groups {
+		first, last := g[0], g[len(g)-1]
+
+		_, _ = fmt.Fprintf(&sb, "@@ -%s +%s @@", diffRange(first.I1, last.I2), diffRange(first.J1, last.J2)) //nolint: errcheck
+
+		if annotate != nil {
+			for _, c := range g {
+				if c.Tag != 'e' {
+					if a := annotate(c); a != "" {
+						sb.WriteString(" " + a)
+					}
+
+					break
+				}
+			}
+		}
+
+		sb.WriteString("\n")
+
+		for _, c := range g {
+			if c.Tag == 'e' {
+				writeDiffLines(&sb, " ", expected[c.I1:c.I2])
+
+				continue
+			}
+
+			if c.Tag == 'r' || c.Tag == 'd' {
+				writeDiffLines(&sb, "-", expected[c.I1:c.I2])
+			}
+
+			if c.Tag == 'r' || c.Tag == 'i' {
+				writeDiffLines(&sb, "+", actual[c.J1:c.J2])
+			}
+		}
+	}
+
+	return sb.String()
+}
+
+func writeDiffLines(sb *strings.Builder, prefix string, lines []string) {
+	for _, l := range lines {
+		sb.WriteString(prefix)
+		sb.WriteString(l)
+		sb.WriteString("\n")
+	}
+}
+
+// diffRange formats a range of lines of a hunk header.
+func diffRange(start, stop int) string {
+	beginning := start + 1
+	length := stop - start
+
+	if length == 1 {
+		return fmt.Sprintf("%d", beginning)
+	}
+
+	if length == 0 {
+		beginning--
+	}
+
+	return fmt.Sprintf("%d,%d", beginning, length)
+}
+
+// prettyJSON prints the JSON value in multiple lines, with the keys sorted. It also returns the path of each line.
+func prettyJSON(v any) (lines []string, paths []string) {
+	var write func(v any, path, indent, prefix, suffix string)
+
+	add := func(path, line string) {
+		lines = append(lines, line)
+		paths = append(paths, path)
+	}
+
+	write = func(v any, path, indent, prefix, suffix string) {
+		switch v := v.(type) {
+		case map[string]any:
+			if len(v) == 0 {
+				add(path, indent+prefix+"{}"+suffix)
+
+				return
+			}
+
+			add(path, indent+prefix+"{")
+
+			keys := sortedJSONKeys(v)
+
+			for i, k := range keys {
+				childPath := path + jsonPathSegment{key: k}.String()
+				write(v[k], childPath, indent+"  ", jsonText(k)+": ", jsonSeparator(i, len(keys)))
+			}
+
+			add(path, indent+"}"+suffix)
+
+		case []any:
+			if len(v) == 0 {
+				add(path, indent+prefix+"[]"+suffix)
+
+				return
+			}
+
+			add(path, indent+prefix+"[")
+
+			for i, e := range v {
+				write(e, path+jsonPathSegment{selector: jsonPathIndex, index: i}.String(), indent+"  ", "", jsonSeparator(i, len(v)))
+			}
+
+			add(path, indent+"]"+suffix)
+
+		default:
+			add(path, indent+prefix+jsonText(v)+suffix)
+		}
+	}
+
+	write(v, "$", "", "", "")
+
+	return lines, paths
+}
+
+func jsonSeparator(i, n int) string {
+	if i < n-1 {
+		return ","
+	}
+
+	return ""
+}
+
+// jsonText marshals a scalar without escaping the HTML characters.
+func jsonText(v any) string {
+	var buf bytes.Buffer
+
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+
+	_ = enc.Encode(v) //nolint: errcheck,errchkjson
+
+	return strings.TrimSuffix(buf.String(), "\n")
+}
+
+// diffJSON returns the unified diff of two JSON values. The hunks are annotated with the path of the first change.
+func diffJSON(expected, actual any) string {
+	expectedLines, expectedPaths := prettyJSON(expected)
+	actualLines, actualPaths := prettyJSON(actual)
+
+	return unifiedDiff(expectedLines, actualLines, func(c difflib.OpCode) string {
+		// Skip the lines that only differ by the trailing comma, like the last field before an added one.
+		i, j := c.I1, c.J1
+
+		for i < c.I2 && j < c.J2 && strings.TrimSuffix(expectedLines[i], ",") == strings.TrimSuffix(actualLines[j], ",") {
+			i++
+			j++
+		}
+
+		switch {
+		case i < c.I2:
+			return expectedPaths[i]
+
+		case j < c.J2:
+			return actualPaths[j]
+
+		case c.I1 < c.I2:
+			return expectedPaths[c.I1]
+		}
+
+		return actualPaths[c.J1]
+	})
+}
+
+// Diff returns a unified diff of the expectation and the actual, or an empty string if the actual matches.
+func (m equalMatcher) Diff(actual any) string {
+	if ok, _ := m.Match(actual); ok { //nolint: errcheck
+		return ""
+	}
+
+	return unifiedDiff(splitLines(prettyVal(m.expected)), splitLines(prettyVal(actual)), nil)
+}
+
+// Diff returns a unified diff of the pretty-printed expectation and actual, or an empty string if the actual matches.
+// The hunks are annotated with the JSON path of the first change, and the differences allowed by the options are not
+// shown.
+//
+// An error is not a match: if the actual could not be marshaled, the diff is between the texts, and if an embedded
+// matcher fails, it is shown as not matched.
+func (m jsonMatcher) Diff(actual any) string {
+	if ok, _ := m.Match(actual); ok { //nolint: errcheck
+		return ""
+	}
+
+	actualBytes, err := jsonVal(actual)
+	if err != nil {
+		return unifiedDiff(splitLines(m.expected), splitLines(prettyVal(actual)), nil)
+	}
+
+	exp, act, ok := m.config.decode([]byte(m.expected), actualBytes)
+	if !ok {
+		return unifiedDiff(splitLines(m.expected), splitLines(string(actualBytes)), nil)
+	}
+
+	if n, err := m.config.normalize(exp, act); err == nil {
+		act = n
+	}
+
+	return diffJSON(jsonPlaceholders(exp), jsonPlaceholders(act))
+}
+
+// Diff returns a unified diff of the expectation and the actual, converted to JSON, or an empty string if the actual
+// matches. If the actual is not a valid YAML, or could not be marshaled, the diff is between the texts.
+func (m yamlMatcher) Diff(actual any) string {
+	actualBytes, ok, err := yamlActual(actual)
+	if !ok || err != nil {
+		return unifiedDiff(splitLines(m.expected), splitLines(prettyVal(actual)), nil)
+	}
+
+	return m.json.Diff(actualBytes)
+}
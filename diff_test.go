@@ -0,0 +1,200 @@
+package matcher_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"go.nhat.io/matcher/v3"
+)
+
+func TestDiff(t *testing.T) {
+	t.Parallel()
+
+	type user struct {
+		ID   int
+		Name string
+	}
+
+	testCases := []struct {
+		scenario string
+		matcher  matcher.Matcher
+		actual   any
+		expected string
+	}{
+		{
+			scenario: "equal - matched",
+			matcher:  matcher.Equal("foobar"),
+			actual:   "foobar",
+		},
+		{
+			scenario: "equal - string",
+			matcher:  matcher.Equal("foo\nbar\nbaz"),
+			actual:   "foo\nqux\nbaz",
+			expected: "--- Expected\n+++ Actual\n@@ -1,3 +1,3 @@\n foo\n-bar\n+qux\n baz\n",
+		},
+		{
+			scenario: "equal - struct",
+			matcher:  matcher.Equal(user{ID: 1, Name: "John"}),
+			actual:   user{ID: 1, Name: "Jane"},
+			expected: `--- Expected
++++ Actual
+@@ -1,4 +1,4 @@
+ (matcher_test.user) {
+  ID: (int) 1,
+- Name: (string) (len=4) "John"
++ Name: (string) (len=4) "Jane"
+ }
+`,
+		},
+		{
+			scenario: "no differ - matched",
+			matcher:  matcher.HasPrefix("foo"),
+			actual:   "foobar",
+		},
+		{
+			scenario: "no differ - mismatched",
+			matcher:  matcher.HasPrefix("foo"),
+			actual:   "bar",
+			expected: "--- Expected\n+++ Actual\n@@ -1 +1 @@\n-has prefix \"foo\"\n+bar\n",
+		},
+		{
+			scenario: "json - matched",
+			matcher:  matcher.JSON(`{"id": 1}`),
+			actual:   `{"id":1}`,
+		},
+		{
+			scenario: "json - path annotated",
+			matcher:  matcher.JSON(`{"id": 1, "items": [{"name": "a", "price": 10}, {"name": "b", "price": 20}]}`),
+			actual:   `{"id": 1, "items": [{"name": "a", "price": 10}, {"name": "b", "price": 25}]}`,
+			expected: `--- Expected
++++ Actual
+@@ -7,7 +7,7 @@ $.items[1].price
+     },
+     {
+       "name": "b",
+-      "price": 20
++      "price": 25
+     }
+   ]
+ }
+`,
+		},
+		{
+			scenario: "json - extra field",
+			matcher:  matcher.JSON(`{"id": 1}`),
+			actual:   `{"id": 1, "name": "John"}`,
+			expected: `--- Expected
++++ Actual
+@@ -1,3 +1,4 @@ $.name
+ {
+-  "id": 1
++  "id": 1,
++  "name": "John"
+ }
+`,
+		},
+		{
+			scenario: "json - missing element",
+			matcher:  matcher.JSON(`[1, 2]`),
+			actual:   `[1]`,
+			expected: `--- Expected
++++ Actual
+@@ -1,4 +1,3 @@ $[1]
+ [
+-  1,
+-  2
++  1
+ ]
+`,
+		},
+		{
+			scenario: "json - options",
+			matcher:  matcher.JSON(`{"at": "<ignore-diff>", "id": 1, "ids": [1, 2]}`, matcher.IgnoreExtraFields(), matcher.IgnoreArrayOrder()),
+			actual:   `{"at": "today", "id": 2, "ids": [2, 1], "name": "John"}`,
+			expected: `--- Expected
++++ Actual
+@@ -1,6 +1,6 @@ $.id
+ {
+   "at": "<ignore-diff>",
+-  "id": 1,
++  "id": 2,
+   "ids": [
+     1,
+     2
+`,
+		},
+		{
+			scenario: "json - embedded matcher",
+			matcher:  matcher.JSON(map[string]any{"id": matcher.GreaterThan(10), "name": "John"}),
+			actual:   `{"id": 20, "name": "Jane"}`,
+			expected: `--- Expected
++++ Actual
+@@ -1,4 +1,4 @@ $.name
+ {
+   "id": "<> 10>",
+-  "name": "John"
++  "name": "Jane"
+ }
+`,
+		},
+		{
+			scenario: "json - invalid actual",
+			matcher:  matcher.JSON(`{"id": 1}`),
+			actual:   `{"id":`,
+			expected: "--- Expected\n+++ Actual\n@@ -1 +1 @@\n-{\"id\": 1}\n+{\"id\":\n",
+		},
+		{
+			scenario: "yaml",
+			matcher:  matcher.YAML("id: 1\nname: John\n"),
+			actual:   "name: Jane\nid: 1\n",
+			expected: `--- Expected
++++ Actual
+@@ -1,4 +1,4 @@ $.name
+ {
+   "id": 1,
+-  "name": "John"
++  "name": "Jane"
+ }
+`,
+		},
+		{
+			scenario: "json - unsupported actual",
+			matcher:  matcher.JSON(`{"id": 1}`),
+			actual:   (chan int)(nil),
+			expected: "--- Expected\n+++ Actual\n@@ -1 +1 @@\n-{\"id\": 1}\n+(chan int) <nil>\n",
+		},
+		{
+			scenario: "json - embedded matcher error",
+			matcher:  matcher.JSON(map[string]any{"id": matcher.Len(1)}),
+			actual:   `{"id": 5}`,
+			expected: `--- Expected
++++ Actual
+@@ -1,3 +1,3 @@ $.id
+ {
+-  "id": "<len is 1>"
++  "id": 5
+ }
+`,
+		},
+		{
+			scenario: "yaml - unsupported actual",
+			matcher:  matcher.YAML("id: 1\n"),
+			actual:   (func())(nil),
+			expected: "--- Expected\n+++ Actual\n@@ -1 +1 @@\n-id: 1\n+(func()) <nil>\n",
+		},
+		{
+			scenario: "yaml - matched",
+			matcher:  matcher.YAML("id: 1\n"),
+			actual:   map[string]any{"id": 1},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.scenario, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tc.expected, matcher.Diff(tc.matcher, tc.actual))
+		})
+	}
+}
@@ -1,15 +1,23 @@
 package matcher
 
-import "fmt"
+import (
+	"errors"
+	"reflect"
+)
 
-func recovered(v any) string {
-	switch v := v.(type) {
-	case error:
-		return v.Error()
+var (
+	// ErrUnknownMatcher indicates that a matcher name is not registered.
+	ErrUnknownMatcher = errors.New("unknown matcher")
+	// ErrDuplicateMatcher indicates that a matcher name is already registered.
+	ErrDuplicateMatcher = errors.New("matcher is already registered")
+)
 
-	case string:
-		return v
-	}
+// LenError is returned when the length of a value is requested but the value does not have a length.
+type LenError struct {
+	Type reflect.Type
+}
 
-	return fmt.Sprintf("%+v", v)
+// Error returns the error message.
+func (e *LenError) Error() string {
+	return "len: value of type " + e.Type.String() + " does not have a length"
 }
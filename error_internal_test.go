@@ -1,13 +1,13 @@
 package matcher
 
 import (
-	"errors"
+	"reflect"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 )
 
-func TestRecovered(t *testing.T) {
+func TestLenError(t *testing.T) {
 	t.Parallel()
 
 	testCases := []struct {
@@ -16,19 +16,14 @@ func TestRecovered(t *testing.T) {
 		expected string
 	}{
 		{
-			scenario: "string",
-			input:    "foobar",
-			expected: "foobar",
-		},
-		{
-			scenario: "error",
-			input:    errors.New("foobar"),
-			expected: "foobar",
+			scenario: "int",
+			input:    42,
+			expected: "len: value of type int does not have a length",
 		},
 		{
-			scenario: "integer",
-			input:    42,
-			expected: "42",
+			scenario: "pointer",
+			input:    (*[]int)(nil),
+			expected: "len: value of type *[]int does not have a length",
 		},
 	}
 
@@ -36,9 +31,9 @@ func TestRecovered(t *testing.T) {
 		t.Run(tc.scenario, func(t *testing.T) {
 			t.Parallel()
 
-			actual := recovered(tc.input)
+			actual := &LenError{Type: reflect.TypeOf(tc.input)}
 
-			assert.Equal(t, tc.expected, actual)
+			assert.Equal(t, tc.expected, actual.Error())
 		})
 	}
 }
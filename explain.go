@@ -0,0 +1,102 @@
+package matcher
+
+import (
+	"strings"
+
+	"go.nhat.io/matcher/v3/format"
+)
+
+// Explainer explains why the actual does not match the expectation.
+type Explainer interface {
+	// Explain returns nil if the actual matches the expectation, otherwise a mismatch describing the reasons.
+	Explain(actual any) *Mismatch
+}
+
+// Mismatch describes why a value does not match the expectation.
+type Mismatch struct {
+	// Path is the location of the mismatched value, relative to its parent.
+	Path string
+	// Expected is the expectation of the matcher.
+	Expected string
+	// Actual is the value that was seen.
+	Actual any
+	// Reason describes what was seen, if it is not obvious from the actual value.
+	Reason string
+	// Err is the error returned by the matcher, if any.
+	Err error
+	// Children are the mismatches of the sub-matchers.
+	Children []*Mismatch
+}
+
+// String returns the mismatch tree as a human-readable text.
+func (m *Mismatch) String() string {
+	var sb strings.Builder
+
+	m.write(&sb, 0)
+
+	return sb.String()
+}
+
+func (m *Mismatch) write(sb *strings.Builder, depth int) {
+	sb.WriteString(strings.Repeat("  ", depth))
+
+	if m.Path != "" {
+		sb.WriteString(m.Path)
+		sb.WriteString(": ")
+	}
+
+	sb.WriteString("expected ")
+	sb.WriteString(m.Expected)
+	sb.WriteString(", ")
+
+	switch {
+	case m.Err != nil:
+		sb.WriteString("error: ")
+		sb.WriteString(m.Err.Error())
+
+	case m.Reason != "":
+		sb.WriteString(m.Reason)
+
+	case m.Actual == nil:
+		sb.WriteString("got nil")
+
+	default:
+		sb.WriteString("got ")
+		sb.WriteString(format.Sprintf("%#v", m.Actual))
+	}
+
+	for _, c := range m.Children {
+		sb.WriteString("\n")
+		c.write(sb, depth+1)
+	}
+}
+
+// Explain explains why the actual does not match the matcher. It returns nil if the actual matches.
+//
+// If the matcher does not implement Explainer, the mismatch is built from the result of Match() and Expected().
+func Explain(m Matcher, actual any) *Mismatch {
+	if e, ok := m.(Explainer); ok {
+		return e.Explain(actual)
+	}
+
+	return explain(m, actual, "")
+}
+
+// explain builds a mismatch from the result of Match() and Expected().
+func explain(m Matcher, actual any, reason string) *Mismatch {
+	ok, err := m.Match(actual)
+	if ok && err == nil {
+		return nil
+	}
+
+	return newMismatch(m, actual, reason, err)
+}
+
+func newMismatch(m Matcher, actual any, reason string, err error) *Mismatch {
+	return &Mismatch{
+		Expected: m.Expected(),
+		Actual:   actual,
+		Reason:   reason,
+		Err:      err,
+	}
+}
@@ -0,0 +1,274 @@
+package matcher_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.nhat.io/matcher/v3"
+	"go.nhat.io/matcher/v3/mock"
+)
+
+func mustParseJSON(t *testing.T, data string) matcher.Matcher {
+	t.Helper()
+
+	m, err := matcher.ParseJSON([]byte(data))
+	require.NoError(t, err)
+
+	return m
+}
+
+func TestExplain_Matched(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		scenario string
+		matcher  matcher.Matcher
+		actual   any
+	}{
+		{
+			scenario: "equal",
+			matcher:  matcher.Equal("foo"),
+			actual:   "foo",
+		},
+		{
+			scenario: "json",
+			matcher:  matcher.JSON(`{"id": "<ignore-diff>"}`),
+			actual:   `{"id": 42}`,
+		},
+		{
+			scenario: "regex",
+			matcher:  matcher.Regex("^foo"),
+			actual:   "foobar",
+		},
+		{
+			scenario: "type",
+			matcher:  matcher.IsType[string](),
+			actual:   "foobar",
+		},
+		{
+			scenario: "len",
+			matcher:  matcher.Len(3),
+			actual:   "foo",
+		},
+		{
+			scenario: "empty",
+			matcher:  matcher.IsEmpty(),
+			actual:   "",
+		},
+		{
+			scenario: "not empty",
+			matcher:  matcher.IsNotEmpty(),
+			actual:   "foo",
+		},
+		{
+			scenario: "callback",
+			matcher:  matcher.Match(func() matcher.Matcher { return matcher.Equal("foo") }),
+			actual:   "foo",
+		},
+		{
+			scenario: "or",
+			matcher:  matcher.Or("foo", "bar"),
+			actual:   "bar",
+		},
+		{
+			scenario: "and",
+			matcher:  matcher.And(matcher.Len(3), matcher.Regex("^b")),
+			actual:   "bar",
+		},
+		{
+			scenario: "not",
+			matcher:  matcher.Not("foo"),
+			actual:   "bar",
+		},
+		{
+			scenario: "json value",
+			matcher:  mustParseJSON(t, `[1, 2]`),
+			actual:   []int{1, 2},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.scenario, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Nil(t, matcher.Explain(tc.matcher, tc.actual))
+		})
+	}
+}
+
+func TestExplain_Mismatched(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		scenario string
+		matcher  matcher.Matcher
+		actual   any
+		expected string
+	}{
+		{
+			scenario: "equal",
+			matcher:  matcher.Equal("foo"),
+			actual:   "bar",
+			expected: `expected foo, got string("bar")`,
+		},
+		{
+			scenario: "equal nil",
+			matcher:  matcher.Equal("foo"),
+			actual:   nil,
+			expected: `expected foo, got nil`,
+		},
+		{
+			scenario: "not empty nil",
+			matcher:  matcher.IsNotEmpty(),
+			actual:   nil,
+			expected: `expected is not empty, got nil`,
+		},
+		{
+			scenario: "json",
+			matcher:  matcher.JSON(`{"id": 42}`),
+			actual:   `[]`,
+			expected: `expected {"id": 42}, types mismatch, object expected`,
+		},
+		{
+			scenario: "json error",
+			matcher:  matcher.JSON(`{"id": 42}`),
+			actual:   make(chan int),
+			expected: `expected {"id": 42}, error: json: unsupported type: chan int`,
+		},
+		{
+			scenario: "regex",
+			matcher:  matcher.Regex("^foo"),
+			actual:   "bar",
+			expected: `expected ^foo, got string("bar")`,
+		},
+		{
+			scenario: "regex not a string",
+			matcher:  matcher.Regex("^foo"),
+			actual:   42,
+			expected: `expected ^foo, got int, not a string`,
+		},
+		{
+			scenario: "type",
+			matcher:  matcher.IsType[string](),
+			actual:   42,
+			expected: `expected type is string, got type int`,
+		},
+		{
+			scenario: "len",
+			matcher:  matcher.Len(3),
+			actual:   []int{1, 2},
+			expected: `expected len is 3, got len 2`,
+		},
+		{
+			scenario: "len error",
+			matcher:  matcher.Len(3),
+			actual:   42,
+			expected: `expected len is 3, error: len: value of type int does not have a length`,
+		},
+		{
+			scenario: "func",
+			matcher: matcher.Func("is positive", func(actual any) (bool, error) {
+				return actual.(int) > 0, nil //nolint: forcetypeassert
+			}),
+			actual:   -1,
+			expected: `expected is positive, got int(-1)`,
+		},
+		{
+			scenario: "or",
+			matcher:  matcher.Or("foo", matcher.Regex("^bar")),
+			actual:   "baz",
+			expected: `expected foo or ^bar, got string("baz")
+  expected foo, got string("baz")
+  expected ^bar, got string("baz")`,
+		},
+		{
+			scenario: "and",
+			matcher:  matcher.And(matcher.Len(3), matcher.Regex("^b")),
+			actual:   "foo",
+			expected: `expected len is 3 and ^b, got string("foo")
+  expected ^b, got string("foo")`,
+		},
+		{
+			scenario: "not",
+			matcher:  matcher.Not(matcher.Or("foo", "bar")),
+			actual:   "foo",
+			expected: `expected not (foo or bar), got string("foo")`,
+		},
+		{
+			scenario: "nested",
+			matcher:  matcher.And(matcher.Regex("^b"), matcher.Or(matcher.Len(4), matcher.Len(5))),
+			actual:   "bar",
+			expected: `expected ^b and (len is 4 or len is 5), got string("bar")
+  expected (len is 4 or len is 5), got string("bar")
+    expected len is 4, got len 3
+    expected len is 5, got len 3`,
+		},
+		{
+			scenario: "json value",
+			matcher:  mustParseJSON(t, `{"$eq": 3}`),
+			actual:   4,
+			expected: `expected 3, got int(4)`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.scenario, func(t *testing.T) {
+			t.Parallel()
+
+			actual := matcher.Explain(tc.matcher, tc.actual)
+
+			require.NotNil(t, actual)
+			assert.Equal(t, tc.expected, actual.String())
+		})
+	}
+}
+
+func TestExplain_NotExplainer(t *testing.T) {
+	t.Parallel()
+
+	m := mock.Mock(func(m *mock.Matcher) {
+		m.On("Match", "foo").Return(false, errors.New("match error"))
+		m.On("Expected").Return("foo")
+	})(t)
+
+	actual := matcher.Explain(m, "foo")
+	expected := &matcher.Mismatch{
+		Expected: "foo",
+		Actual:   "foo",
+		Err:      errors.New("match error"),
+	}
+
+	assert.Equal(t, expected, actual)
+}
+
+func TestExplain_LogicalError(t *testing.T) {
+	t.Parallel()
+
+	m := matcher.Or(matcher.Len(3), "foo")
+
+	actual := matcher.Explain(m, 42)
+
+	require.NotNil(t, actual)
+	require.Len(t, actual.Children, 1)
+	require.EqualError(t, actual.Children[0].Err, `len: value of type int does not have a length`)
+}
+
+func TestMismatch_String_Path(t *testing.T) {
+	t.Parallel()
+
+	m := &matcher.Mismatch{
+		Expected: "len is 1",
+		Actual:   []int{},
+		Children: []*matcher.Mismatch{
+			{Path: "[0]", Expected: "foo", Actual: "bar"},
+		},
+	}
+
+	expected := `expected len is 1, got []int{}
+  [0]: expected foo, got string("bar")`
+
+	assert.Equal(t, expected, m.String())
+}
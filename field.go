@@ -0,0 +1,231 @@
+package matcher
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"unsafe"
+)
+
+var (
+	errNotStruct          = errors.New("not a struct")
+	errFieldNotFound      = errors.New("field not found")
+	errFieldUnexported    = errors.New("field is unexported")
+	errFieldNilPointer    = errors.New("nil pointer")
+	errFieldEmbeddedIsNil = errors.New("embedded pointer is nil")
+)
+
+// FieldOption configures the field matchers.
+type FieldOption func(c *fieldConfig)
+
+type fieldConfig struct {
+	unexported bool
+}
+
+// WithUnexportedFields allows the field matchers to read unexported fields.
+func WithUnexportedFields() FieldOption {
+	return func(c *fieldConfig) {
+		c.unexported = true
+	}
+}
+
+// fieldVal gets the value of a field by a dotted path. Pointers to structs and promoted fields of embedded structs are
+// supported.
+func fieldVal(v any, path string, unexported bool) (any, error) {
+	val := reflect.ValueOf(v)
+	if !val.IsValid() {
+		return nil, errFieldNilPointer
+	}
+
+	if val.Kind() == reflect.Struct {
+		// Make a copy so that the unexported fields are addressable.
+		c := reflect.New(val.Type()).Elem()
+		c.Set(val)
+
+		val = c
+	}
+
+	names := strings.Split(path, ".")
+
+	for i, name := range names {
+		for val.Kind() == reflect.Ptr || val.Kind() == reflect.Interface {
+			if val.IsNil() {
+				return nil, errFieldNilPointer
+			}
+
+			val = val.Elem()
+		}
+
+		if val.Kind() != reflect.Struct {
+			return nil, errNotStruct
+		}
+
+		f, ok := val.Type().FieldByName(name)
+		if !ok {
+			return nil, errFieldNotFound
+		}
+
+		// Embedded structs could be traversed to reach their exported fields.
+		if !f.IsExported() && !unexported && (!f.Anonymous || i == len(names)-1) {
+			return nil, errFieldUnexported
+		}
+
+		fv, err := val.FieldByIndexErr(f.Index)
+		if err != nil {
+			return nil, errFieldEmbeddedIsNil
+		}
+
+		val = fv
+	}
+
+	if !val.CanInterface() {
+		if !val.CanAddr() {
+			return nil, errFieldUnexported
+		}
+
+		val = reflect.NewAt(val.Type(), unsafe.Pointer(val.UnsafeAddr())).Elem() //nolint: gosec
+	}
+
+	return val.Interface(), nil
+}
+
+// fieldError returns the error of a field that could not be read because of the expectation, like a field that does not
+// exist, or nil if it is a mismatch, like a nil pointer or an unexported field without WithUnexportedFields().
+func fieldError(path string, err error) error {
+	if errors.Is(err, errFieldNotFound) || errors.Is(err, errNotStruct) {
+		return fmt.Errorf("%w: %s", err, path)
+	}
+
+	return nil
+}
+
+var (
+	_ Matcher   = (*fieldsMatcher)(nil)
+	_ Explainer = (*fieldsMatcher)(nil)
+)
+
+// fieldsMatcher matches the fields of a struct.
+type fieldsMatcher struct {
+	paths      []string
+	values     []element
+	unexported bool
+	expected   string
+	operator   string
+}
+
+// Match determines if the actual is expected.
+func (m fieldsMatcher) Match(actual any) (bool, error) {
+	for i, path := range m.paths {
+		v, err := fieldVal(actual, path, m.unexported)
+		if err != nil {
+			return false, fieldError(path, err)
+		}
+
+		if ok, err := m.values[i].matcher.Match(v); err != nil || !ok {
+			return false, err
+		}
+	}
+
+	return true, nil
+}
+
+// Expected returns the expectation.
+func (m fieldsMatcher) Expected() string {
+	return m.expected
+}
+
+// Explain explains why the actual is not expected.
+func (m fieldsMatcher) Explain(actual any) *Mismatch {
+	var children []*Mismatch
+
+	for i, path := range m.paths {
+		v, err := fieldVal(actual, path, m.unexported)
+		if ferr := fieldError(path, err); ferr != nil {
+			return newMismatch(m, actual, "", ferr)
+		}
+
+		if err != nil {
+			children = append(children, &Mismatch{Path: path, Expected: m.values[i].Expected(), Reason: err.Error()})
+
+			continue
+		}
+
+		if c := Explain(m.values[i].matcher, v); c != nil {
+			c.Path = path
+			children = append(children, c)
+		}
+	}
+
+	if len(children) == 0 {
+		return nil
+	}
+
+	result := newMismatch(m, actual, "", nil)
+	result.Children = children
+
+	return result
+}
+
+func (m fieldsMatcher) Format(s fmt.State, _ rune) {
+	_, _ = fmt.Fprintf(s, "<%s>", m.expected) //nolint: errcheck
+}
+
+func fieldOptions(opts []FieldOption) fieldConfig {
+	var c fieldConfig
+
+	for _, o := range opts {
+		o(&c)
+	}
+
+	return c
+}
+
+// HasField matches if the actual is a struct, or a pointer to a struct, that has a field whose value matches the
+// expectation. The expectation is converted using Match().
+//
+// The path could be dotted to reach the fields of nested structs, like "Address.City". Promoted fields of embedded
+// structs are supported. Unexported fields are only read with WithUnexportedFields().
+//
+// A field that does not exist, or a value on the path that is not a struct, is an error, because it is likely a typo in
+// the path. A nil pointer on the path, or an unexported field without WithUnexportedFields(), is a mismatch.
+func HasField(path string, expected any, opts ...FieldOption) Matcher {
+	v := element{value: expected, matcher: makeNestedMatcher(expected)}
+
+	return fieldsMatcher{
+		paths:      []string{path},
+		values:     []element{v},
+		unexported: fieldOptions(opts).unexported,
+		expected:   "has field " + path + " with value " + v.Expected(),
+		operator:   operatorHasField,
+	}
+}
+
+// Fields matches if the actual is a struct, or a pointer to a struct, whose fields match all the expectations. The keys
+// are the paths of the fields, see HasField.
+func Fields(expected map[string]any, opts ...FieldOption) Matcher {
+	m := fieldsMatcher{
+		paths:      make([]string, 0, len(expected)),
+		unexported: fieldOptions(opts).unexported,
+		operator:   operatorFields,
+	}
+
+	for path := range expected {
+		m.paths = append(m.paths, path)
+	}
+
+	sort.Strings(m.paths)
+
+	m.values = make([]element, len(m.paths))
+	fields := make([]string, len(m.paths))
+
+	for i, path := range m.paths {
+		m.values[i] = element{value: expected[path], matcher: makeNestedMatcher(expected[path])}
+		fields[i] = path + ": " + m.values[i].Expected()
+	}
+
+	m.expected = "has fields {" + strings.Join(fields, ", ") + "}"
+
+	return m
+}
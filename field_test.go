@@ -0,0 +1,274 @@
+package matcher_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.nhat.io/matcher/v3"
+)
+
+type fieldTestBase struct {
+	ID      int
+	created string
+}
+
+type fieldTestAddress struct {
+	City string
+	zip  string
+}
+
+type fieldTestUser struct {
+	fieldTestBase
+
+	Name     string
+	Address  fieldTestAddress
+	Previous *fieldTestAddress
+	Extra    any
+	password string
+}
+
+func newFieldTestUser() fieldTestUser {
+	return fieldTestUser{
+		fieldTestBase: fieldTestBase{ID: 42, created: "yesterday"},
+		Name:          "John",
+		Address:       fieldTestAddress{City: "Paris", zip: "75001"},
+		Extra:         fieldTestAddress{City: "Berlin"},
+		password:      "secret",
+	}
+}
+
+func TestFields_Match(t *testing.T) {
+	t.Parallel()
+
+	user := newFieldTestUser()
+
+	testCases := []struct {
+		scenario string
+		matcher  matcher.Matcher
+		actual   any
+		expected bool
+	}{
+		{
+			scenario: "field",
+			matcher:  matcher.HasField("Name", "John"),
+			actual:   user,
+			expected: true,
+		},
+		{
+			scenario: "field - pointer",
+			matcher:  matcher.HasField("Name", "John"),
+			actual:   &user,
+			expected: true,
+		},
+		{
+			scenario: "field - matcher",
+			matcher:  matcher.HasField("Name", matcher.HasPrefix("Jo")),
+			actual:   user,
+			expected: true,
+		},
+		{
+			scenario: "field - mismatched",
+			matcher:  matcher.HasField("Name", "Jane"),
+			actual:   user,
+		},
+		{
+			scenario: "field - nil",
+			matcher:  matcher.HasField("Name", matcher.Any),
+			actual:   nil,
+		},
+		{
+			scenario: "field - nil pointer",
+			matcher:  matcher.HasField("Name", matcher.Any),
+			actual:   (*fieldTestUser)(nil),
+		},
+		{
+			scenario: "nested",
+			matcher:  matcher.HasField("Address.City", "Paris"),
+			actual:   user,
+			expected: true,
+		},
+		{
+			scenario: "nested - nil pointer",
+			matcher:  matcher.HasField("Previous.City", matcher.Any),
+			actual:   user,
+		},
+		{
+			scenario: "nested - interface",
+			matcher:  matcher.HasField("Extra.City", "Berlin"),
+			actual:   user,
+			expected: true,
+		},
+		{
+			scenario: "nested - struct",
+			matcher:  matcher.HasField("Address", fieldTestAddress{City: "Paris", zip: "75001"}),
+			actual:   user,
+			expected: true,
+		},
+		{
+			scenario: "embedded - promoted",
+			matcher:  matcher.HasField("ID", 42),
+			actual:   user,
+			expected: true,
+		},
+		{
+			scenario: "embedded - explicit",
+			matcher:  matcher.HasField("fieldTestBase.ID", 42),
+			actual:   &user,
+			expected: true,
+		},
+		{
+			scenario: "unexported",
+			matcher:  matcher.HasField("password", matcher.Any),
+			actual:   user,
+		},
+		{
+			scenario: "unexported - opt in",
+			matcher:  matcher.HasField("password", "secret", matcher.WithUnexportedFields()),
+			actual:   user,
+			expected: true,
+		},
+		{
+			scenario: "unexported - opt in pointer",
+			matcher:  matcher.HasField("password", "secret", matcher.WithUnexportedFields()),
+			actual:   &user,
+			expected: true,
+		},
+		{
+			scenario: "unexported - nested",
+			matcher:  matcher.HasField("Address.zip", "75001", matcher.WithUnexportedFields()),
+			actual:   user,
+			expected: true,
+		},
+		{
+			scenario: "unexported - embedded",
+			matcher:  matcher.HasField("created", "yesterday", matcher.WithUnexportedFields()),
+			actual:   user,
+			expected: true,
+		},
+		{
+			scenario: "fields",
+			matcher: matcher.Fields(map[string]any{
+				"ID":           matcher.GreaterThan(40),
+				"Name":         "John",
+				"Address.City": matcher.Regex("^P"),
+			}),
+			actual:   user,
+			expected: true,
+		},
+		{
+			scenario: "fields - mismatched",
+			matcher: matcher.Fields(map[string]any{
+				"ID":   matcher.GreaterThan(40),
+				"Name": "Jane",
+			}),
+			actual: user,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.scenario, func(t *testing.T) {
+			t.Parallel()
+
+			result, err := tc.matcher.Match(tc.actual)
+
+			assert.Equal(t, tc.expected, result)
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestFields_Match_Error(t *testing.T) {
+	t.Parallel()
+
+	user := newFieldTestUser()
+
+	testCases := []struct {
+		scenario      string
+		matcher       matcher.Matcher
+		actual        any
+		expectedError string
+	}{
+		{
+			scenario:      "value error",
+			matcher:       matcher.HasField("ID", matcher.Len(1)),
+			actual:        user,
+			expectedError: `len: value of type int does not have a length`,
+		},
+		{
+			scenario:      "not found",
+			matcher:       matcher.HasField("Nmae", matcher.Any),
+			actual:        user,
+			expectedError: `field not found: Nmae`,
+		},
+		{
+			scenario:      "nested - not found",
+			matcher:       matcher.Fields(map[string]any{"Name": "John", "Address.Street": matcher.Any}),
+			actual:        &user,
+			expectedError: `field not found: Address.Street`,
+		},
+		{
+			scenario:      "not a struct",
+			matcher:       matcher.HasField("Name", matcher.Any),
+			actual:        "John",
+			expectedError: `not a struct: Name`,
+		},
+		{
+			scenario:      "nested - not a struct",
+			matcher:       matcher.HasField("Name.First", matcher.Any),
+			actual:        user,
+			expectedError: `not a struct: Name.First`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.scenario, func(t *testing.T) {
+			t.Parallel()
+
+			result, err := tc.matcher.Match(tc.actual)
+
+			assert.False(t, result)
+			require.EqualError(t, err, tc.expectedError)
+
+			actual := matcher.Explain(tc.matcher, tc.actual)
+
+			require.NotNil(t, actual)
+			assert.Contains(t, actual.String(), tc.expectedError)
+		})
+	}
+}
+
+func TestFields_Expected(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, `has field Name with value "John"`, matcher.HasField("Name", "John").Expected())
+	assert.Equal(t, `has fields {Address.City: ^P, ID: > 1}`, matcher.Fields(map[string]any{
+		"ID":           matcher.GreaterThan(1),
+		"Address.City": matcher.Regex("^P"),
+	}).Expected())
+	assert.Equal(t, `<has field ID with value 1>`, fmt.Sprintf("%#v", matcher.HasField("ID", 1)))
+}
+
+func TestFields_Explain(t *testing.T) {
+	t.Parallel()
+
+	m := matcher.Fields(map[string]any{
+		"ID":            matcher.LessThan(40),
+		"Name":          "John",
+		"password":      "secret",
+		"Previous.City": "Paris",
+	})
+
+	actual := matcher.Explain(m, newFieldTestUser())
+	expected := `expected has fields {ID: < 40, Name: "John", Previous.City: "Paris", password: "secret"}, got matcher_test.fieldTestUser{fieldTestBase:matcher_test.fieldTestBase{ID:42, created:"yesterday"}, Name:"John", Address:matcher_test.fieldTestAddress{City:"Paris", zip:"75001"}, Previous:(*matcher_test.fieldTestAddress)(nil), Extra:matcher_test.fieldTestAddress{City:"Berlin", zip:""}, password:"secret"}
+  ID: expected < 40, got int(42)
+  Previous.City: expected "Paris", nil pointer
+  password: expected "secret", field is unexported`
+
+	require.NotNil(t, actual)
+	assert.Equal(t, expected, actual.String())
+
+	assert.Nil(t, matcher.Explain(matcher.HasField("Name", "John"), newFieldTestUser()))
+}
@@ -0,0 +1,2 @@
+// Package gomega provides adapters between the matchers and the Gomega matchers.
+package gomega
@@ -0,0 +1,125 @@
+package gomega
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/onsi/gomega/types"
+
+	"go.nhat.io/matcher/v3"
+	"go.nhat.io/matcher/v3/format"
+)
+
+var _ types.GomegaMatcher = (*gomegaMatcher)(nil)
+
+// gomegaMatcher is a Gomega matcher backed by a matcher.
+type gomegaMatcher struct {
+	matcher matcher.Matcher
+}
+
+// Match determines if the actual is expected.
+func (m gomegaMatcher) Match(actual any) (bool, error) {
+	return m.matcher.Match(actual)
+}
+
+// FailureMessage returns the message when the actual is not expected. It has the diff of the expectation and the
+// actual if the matcher supports it.
+func (m gomegaMatcher) FailureMessage(actual any) string {
+	msg := m.message(actual, "to match")
+
+	if _, ok := m.matcher.(matcher.Differ); ok {
+		if diff := matcher.Diff(m.matcher, actual); diff != "" {
+			msg += "\n" + diff
+		}
+	}
+
+	return msg
+}
+
+// NegatedFailureMessage returns the message when the actual is expected, but it should not be.
+func (m gomegaMatcher) NegatedFailureMessage(actual any) string {
+	return m.message(actual, "not to match")
+}
+
+func (m gomegaMatcher) message(actual any, verb string) string {
+	got := "nil"
+
+	if actual != nil {
+		got = format.Sprintf("%#v", actual)
+	}
+
+	return fmt.Sprintf("Expected\n    %s\n%s\n    %s", got, verb, m.matcher.Expected())
+}
+
+var (
+	_ matcher.Matcher   = (*fromGomegaMatcher)(nil)
+	_ matcher.Explainer = (*fromGomegaMatcher)(nil)
+)
+
+// fromGomegaMatcher is a matcher backed by a Gomega matcher.
+type fromGomegaMatcher struct {
+	matcher types.GomegaMatcher
+}
+
+// Match determines if the actual is expected.
+func (m fromGomegaMatcher) Match(actual any) (bool, error) {
+	return m.matcher.Match(actual)
+}
+
+// Expected returns the expectation. The Gomega matchers do not describe themselves, so it is the result of String() if
+// the matcher is a fmt.Stringer, otherwise the type of the matcher.
+func (m fromGomegaMatcher) Expected() string {
+	if s, ok := m.matcher.(fmt.Stringer); ok {
+		return s.String()
+	}
+
+	return strings.TrimPrefix(fmt.Sprintf("%T", m.matcher), "*")
+}
+
+// Explain explains why the actual is not expected, with the failure message of the Gomega matcher.
+func (m fromGomegaMatcher) Explain(actual any) *matcher.Mismatch {
+	matched, err := m.matcher.Match(actual)
+	if matched && err == nil {
+		return nil
+	}
+
+	result := &matcher.Mismatch{Expected: m.Expected(), Actual: actual, Err: err}
+
+	if err == nil {
+		result.Reason = m.matcher.FailureMessage(actual)
+	}
+
+	return result
+}
+
+func (m fromGomegaMatcher) Format(s fmt.State, _ rune) {
+	_, _ = fmt.Fprintf(s, "<%s>", m.Expected()) //nolint: errcheck
+}
+
+// Matcher converts a matcher to a Gomega matcher. The expectation is a matcher.Matcher, or a value that is converted
+// by matcher.Match(). A Gomega matcher is returned as is.
+//
+//	Expect(body).To(gomega.Matcher(matcher.JSON(`{"id": "<ignore-diff>"}`)))
+//
+// The failure messages show the expectation and the actual formatted by format.Sprintf().
+func Matcher(expected any) types.GomegaMatcher {
+	switch m := expected.(type) {
+	case fromGomegaMatcher:
+		return m.matcher
+
+	case types.GomegaMatcher:
+		return m
+	}
+
+	return gomegaMatcher{matcher: matcher.Match(expected)}
+}
+
+// FromGomega converts a Gomega matcher to a matcher, so it could be used with the other matchers, like matcher.And()
+// or matcher.Or().
+func FromGomega(m types.GomegaMatcher) matcher.Matcher {
+	if m, ok := m.(gomegaMatcher); ok {
+		return m.matcher
+	}
+
+	return fromGomegaMatcher{matcher: m}
+}
@@ -0,0 +1,142 @@
+package gomega_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/onsi/gomega"
+	"github.com/stretchr/testify/assert"
+
+	"go.nhat.io/matcher/v3"
+	matchergomega "go.nhat.io/matcher/v3/gomega"
+)
+
+func TestMatcher(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		scenario               string
+		expected               any
+		actual                 any
+		expectedResult         bool
+		expectedError          string
+		expectedFailureMessage string
+		expectedNegatedMessage string
+	}{
+		{
+			scenario:               "matched",
+			expected:               matcher.HasPrefix("foo"),
+			actual:                 "foobar",
+			expectedResult:         true,
+			expectedFailureMessage: "Expected\n    string(\"foobar\")\nto match\n    has prefix \"foo\"",
+			expectedNegatedMessage: "Expected\n    string(\"foobar\")\nnot to match\n    has prefix \"foo\"",
+		},
+		{
+			scenario:               "not matched",
+			expected:               "foobar",
+			actual:                 []string{"foo"},
+			expectedFailureMessage: "Expected\n    []string{\"foo\"}\nto match\n    foobar\n--- Expected\n+++ Actual\n@@ -1 +1,3 @@\n-foobar\n+([]string) (len=1) {\n+ (string) (len=3) \"foo\"\n+}\n",
+			expectedNegatedMessage: "Expected\n    []string{\"foo\"}\nnot to match\n    foobar",
+		},
+		{
+			scenario:               "nil",
+			expected:               matcher.IsNotEmpty(),
+			actual:                 nil,
+			expectedFailureMessage: "Expected\n    nil\nto match\n    is not empty",
+			expectedNegatedMessage: "Expected\n    nil\nnot to match\n    is not empty",
+		},
+		{
+			scenario:               "error",
+			expected:               matcher.Len(1),
+			actual:                 42,
+			expectedError:          "len: value of type int does not have a length",
+			expectedFailureMessage: "Expected\n    int(42)\nto match\n    len is 1",
+			expectedNegatedMessage: "Expected\n    int(42)\nnot to match\n    len is 1",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.scenario, func(t *testing.T) {
+			t.Parallel()
+
+			m := matchergomega.Matcher(tc.expected)
+
+			result, err := m.Match(tc.actual)
+
+			assert.Equal(t, tc.expectedResult, result)
+
+			if tc.expectedError == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tc.expectedError)
+			}
+
+			assert.Equal(t, tc.expectedFailureMessage, m.FailureMessage(tc.actual))
+			assert.Equal(t, tc.expectedNegatedMessage, m.NegatedFailureMessage(tc.actual))
+		})
+	}
+}
+
+func TestMatcher_Expect(t *testing.T) {
+	t.Parallel()
+
+	var messages []string
+
+	g := gomega.NewGomega(func(message string, _ ...int) {
+		messages = append(messages, message)
+	})
+
+	g.Expect(`{"id": 42}`).To(matchergomega.Matcher(matcher.JSON(`{"id": "<ignore-diff>"}`)))
+	g.Expect("bar").NotTo(matchergomega.Matcher(matcher.HasPrefix("foo")))
+	g.Expect("foobar").To(matchergomega.Matcher(matcher.HasSuffix("foo")))
+
+	assert.Equal(t, []string{"Expected\n    string(\"foobar\")\nto match\n    has suffix \"foo\""}, messages)
+}
+
+func TestFromGomega(t *testing.T) {
+	t.Parallel()
+
+	m := matchergomega.FromGomega(gomega.Equal(42))
+
+	result, err := m.Match(42)
+
+	assert.True(t, result)
+	assert.NoError(t, err)
+	assert.Equal(t, `matchers.EqualMatcher`, m.Expected())
+	assert.Equal(t, `<matchers.EqualMatcher>`, fmt.Sprintf("%v", m))
+	assert.Nil(t, matcher.Explain(m, 42))
+
+	result, err = matcher.Or(m, matcher.Equal(43)).Match(43)
+
+	assert.True(t, result)
+	assert.NoError(t, err)
+
+	expected := "expected matchers.EqualMatcher, Expected\n    <int>: 44\nto equal\n    <int>: 42"
+
+	assert.Equal(t, expected, matcher.Explain(m, 44).String())
+}
+
+func TestFromGomega_Error(t *testing.T) {
+	t.Parallel()
+
+	m := matchergomega.FromGomega(gomega.BeNumerically(">", 1))
+
+	result, err := m.Match("foo")
+
+	assert.False(t, result)
+	assert.EqualError(t, err, "Expected a number.  Got:\n    <string>: foo")
+
+	expected := "expected matchers.BeNumericallyMatcher, error: Expected a number.  Got:\n    <string>: foo"
+
+	assert.Equal(t, expected, matcher.Explain(m, "foo").String())
+}
+
+func TestRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	m := matcher.Or("foo", "bar")
+	gm := gomega.Equal(42)
+
+	assert.Same(t, m, matchergomega.FromGomega(matchergomega.Matcher(m)))
+	assert.Same(t, gm, matchergomega.Matcher(matchergomega.FromGomega(gm)))
+}
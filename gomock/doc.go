@@ -0,0 +1,2 @@
+// Package gomock provides adapters between the matchers and the go.uber.org/mock/gomock matchers.
+package gomock
@@ -0,0 +1,109 @@
+package gomock
+
+import (
+	"fmt"
+
+	"go.uber.org/mock/gomock"
+
+	"go.nhat.io/matcher/v3"
+	"go.nhat.io/matcher/v3/format"
+)
+
+var (
+	_ gomock.Matcher      = (*gomockMatcher)(nil)
+	_ gomock.GotFormatter = (*gomockMatcher)(nil)
+)
+
+// gomockMatcher is a gomock matcher backed by a matcher.
+type gomockMatcher struct {
+	matcher matcher.Matcher
+}
+
+// Matches returns whether the actual is a match. An error returned by the matcher is a mismatch.
+func (m gomockMatcher) Matches(actual any) bool {
+	matched, err := m.matcher.Match(actual)
+
+	return matched && err == nil
+}
+
+// String describes what the matcher matches.
+func (m gomockMatcher) String() string {
+	return m.matcher.Expected()
+}
+
+// Got formats the received value for the failure messages.
+func (m gomockMatcher) Got(actual any) string {
+	if actual == nil {
+		return "nil"
+	}
+
+	return format.Sprintf("%#v", actual)
+}
+
+var (
+	_ matcher.Matcher   = (*fromGomockMatcher)(nil)
+	_ matcher.Explainer = (*fromGomockMatcher)(nil)
+)
+
+// fromGomockMatcher is a matcher backed by a gomock matcher.
+type fromGomockMatcher struct {
+	matcher gomock.Matcher
+}
+
+// Match determines if the actual is expected.
+func (m fromGomockMatcher) Match(actual any) (bool, error) {
+	return m.matcher.Matches(actual), nil
+}
+
+// Expected returns the expectation.
+func (m fromGomockMatcher) Expected() string {
+	return m.matcher.String()
+}
+
+// Explain explains why the actual is not expected. The actual is formatted by the gomock matcher if it is a
+// gomock.GotFormatter.
+func (m fromGomockMatcher) Explain(actual any) *matcher.Mismatch {
+	if m.matcher.Matches(actual) {
+		return nil
+	}
+
+	result := &matcher.Mismatch{Expected: m.Expected(), Actual: actual}
+
+	if f, ok := m.matcher.(gomock.GotFormatter); ok {
+		result.Reason = "got " + f.Got(actual)
+	}
+
+	return result
+}
+
+func (m fromGomockMatcher) Format(s fmt.State, _ rune) {
+	_, _ = fmt.Fprintf(s, "<%s>", m.matcher.String()) //nolint: errcheck
+}
+
+// Matcher converts a matcher to a gomock matcher. The expectation is a matcher.Matcher, or a value that is converted
+// by matcher.Match(). A gomock matcher is returned as is.
+//
+//	repo.EXPECT().Save(gomock.Matcher(matcher.JSON(`{"id": "<ignore-diff>"}`)))
+//
+// The failure messages show the expectation and the actual formatted by format.Sprintf().
+func Matcher(expected any) gomock.Matcher {
+	switch m := expected.(type) {
+	case fromGomockMatcher:
+		return m.matcher
+
+	case gomock.Matcher:
+		return m
+	}
+
+	return gomockMatcher{matcher: matcher.Match(expected)}
+}
+
+// FromGomock converts a gomock matcher to a matcher, so it could be used with the other matchers, like matcher.And()
+// or matcher.Or().
+func FromGomock(m gomock.Matcher) matcher.Matcher {
+	if m, ok := m.(gomockMatcher); ok {
+		return m.matcher
+	}
+
+	return fromGomockMatcher{matcher: m}
+}
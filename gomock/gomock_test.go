@@ -0,0 +1,159 @@
+package gomock_test
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"go.nhat.io/matcher/v3"
+	matchergomock "go.nhat.io/matcher/v3/gomock"
+)
+
+func TestMatcher(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		scenario       string
+		expected       any
+		actual         any
+		expectedResult bool
+		expectedString string
+	}{
+		{
+			scenario:       "matcher - matched",
+			expected:       matcher.JSON(`{"id": "<ignore-diff>"}`),
+			actual:         `{"id": 42}`,
+			expectedResult: true,
+			expectedString: `{"id": "<ignore-diff>"}`,
+		},
+		{
+			scenario:       "matcher - not matched",
+			expected:       matcher.HasPrefix("foo"),
+			actual:         "bar",
+			expectedString: `has prefix "foo"`,
+		},
+		{
+			scenario:       "matcher - error",
+			expected:       matcher.Len(1),
+			actual:         42,
+			expectedString: `len is 1`,
+		},
+		{
+			scenario:       "value",
+			expected:       "foobar",
+			actual:         "foobar",
+			expectedResult: true,
+			expectedString: `foobar`,
+		},
+		{
+			scenario:       "gomock matcher",
+			expected:       gomock.Eq(42),
+			actual:         42,
+			expectedResult: true,
+			expectedString: `is equal to 42 (int)`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.scenario, func(t *testing.T) {
+			t.Parallel()
+
+			m := matchergomock.Matcher(tc.expected)
+
+			assert.Equal(t, tc.expectedResult, m.Matches(tc.actual))
+			assert.Equal(t, tc.expectedString, m.String())
+		})
+	}
+}
+
+func TestMatcher_Got(t *testing.T) {
+	t.Parallel()
+
+	m := matchergomock.Matcher(matcher.Equal("foobar"))
+
+	f, ok := m.(gomock.GotFormatter)
+
+	assert.True(t, ok)
+	assert.Equal(t, `string("foo")`, f.Got("foo"))
+	assert.Equal(t, `[]string{"foo"}`, f.Got([]string{"foo"}))
+	assert.Equal(t, `nil`, f.Got(nil))
+}
+
+func TestFromGomock(t *testing.T) {
+	t.Parallel()
+
+	m := matchergomock.FromGomock(gomock.Eq(42))
+
+	result, err := m.Match(42)
+
+	assert.True(t, result)
+	assert.NoError(t, err)
+	assert.Equal(t, `is equal to 42 (int)`, m.Expected())
+	assert.Equal(t, `<is equal to 42 (int)>`, fmt.Sprintf("%v", m))
+	assert.Nil(t, matcher.Explain(m, 42))
+
+	result, err = matcher.And(m, matcher.IsNotEmpty()).Match(43)
+
+	assert.False(t, result)
+	assert.NoError(t, err)
+
+	assert.Equal(t, `expected is equal to 42 (int), got int(43)`, matcher.Explain(m, 43).String())
+}
+
+func TestFromGomock_GotFormatter(t *testing.T) {
+	t.Parallel()
+
+	m := matchergomock.FromGomock(gomock.GotFormatterAdapter(
+		gomock.GotFormatterFunc(func(got any) string { return fmt.Sprintf("a %T", got) }),
+		gomock.Eq(42),
+	))
+
+	assert.Equal(t, `expected is equal to 42 (int), got a string`, matcher.Explain(m, "42").String())
+}
+
+func TestRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	m := matcher.Or("foo", "bar")
+	gm := gomock.Eq(42)
+
+	assert.Same(t, m, matchergomock.FromGomock(matchergomock.Matcher(m)))
+	assert.Equal(t, gm, matchergomock.Matcher(matchergomock.FromGomock(gm)))
+}
+
+type testReporter struct {
+	messages []string
+}
+
+func (r *testReporter) Errorf(format string, args ...any) {
+	r.messages = append(r.messages, fmt.Sprintf(format, args...))
+}
+
+// Fatalf stops the call like testing.T does.
+func (r *testReporter) Fatalf(format string, args ...any) {
+	r.messages = append(r.messages, fmt.Sprintf(format, args...))
+
+	panic(r)
+}
+
+func TestMatcher_Controller(t *testing.T) {
+	t.Parallel()
+
+	r := &testReporter{}
+	ctrl := gomock.NewController(r)
+	receiver := struct{}{}
+	method := reflect.TypeOf(func(string) {})
+
+	ctrl.RecordCallWithMethodType(receiver, "Save", method, matchergomock.Matcher(matcher.HasPrefix("foo")))
+
+	assert.Panics(t, func() {
+		ctrl.Call(receiver, "Save", "bar")
+	})
+
+	assert.Len(t, r.messages, 1)
+	assert.Contains(t, r.messages[0], `Got: string("bar")`)
+	assert.Contains(t, r.messages[0], `Want: has prefix "foo"`)
+}
@@ -1,9 +1,15 @@
 package matcher
 
 import (
+	"cmp"
 	"encoding/json"
+	"fmt"
+	"math"
 	"reflect"
 	"regexp"
+	"sort"
+	"time"
+	"unicode/utf8"
 )
 
 func strVal(v any) *string {
@@ -18,6 +24,24 @@ func strVal(v any) *string {
 	return nil
 }
 
+// textVal is like strVal, but also supports fmt.Stringer and error. A nil pointer is not a text, because its String()
+// or Error() would dereference it.
+func textVal(v any) *string {
+	if val := reflect.ValueOf(v); (val.Kind() == reflect.Ptr || val.Kind() == reflect.Interface) && val.IsNil() {
+		return nil
+	}
+
+	switch v := v.(type) {
+	case fmt.Stringer:
+		return ptr(v.String())
+
+	case error:
+		return ptr(v.Error())
+	}
+
+	return strVal(v)
+}
+
 func jsonVal(v any) ([]byte, error) {
 	switch v := v.(type) {
 	case string:
@@ -30,6 +54,38 @@ func jsonVal(v any) ([]byte, error) {
 	return json.Marshal(v)
 }
 
+// timeVal converts the value to time.Time. Strings and []byte are parsed as RFC3339, quoted or not.
+func timeVal(v any) (time.Time, bool) {
+	switch v := v.(type) {
+	case time.Time:
+		return v, true
+
+	case *time.Time:
+		if v == nil {
+			return time.Time{}, false
+		}
+
+		return *v, true
+	}
+
+	s := strVal(v)
+	if s == nil {
+		return time.Time{}, false
+	}
+
+	if t, err := time.Parse(time.RFC3339Nano, *s); err == nil {
+		return t, true
+	}
+
+	var t time.Time
+
+	if err := json.Unmarshal([]byte(*s), &t); err != nil {
+		return time.Time{}, false
+	}
+
+	return t, true
+}
+
 func regexpVal(v any) *regexp.Regexp {
 	switch v := v.(type) {
 	case *regexp.Regexp:
@@ -71,6 +127,178 @@ func isEmpty(v any) bool {
 	return reflect.DeepEqual(v, zero.Interface())
 }
 
+// lenVal gets the length of the value, or a *LenError if the value does not have a length. If runes is true, strings
+// and []byte are measured by the number of runes.
+//
+// nolint: exhaustive
+func lenVal(v any, runes bool) (int, error) {
+	val := reflect.ValueOf(v)
+
+	if val.Kind() == reflect.Ptr && !val.IsNil() {
+		val = val.Elem()
+	}
+
+	switch val.Kind() {
+	case reflect.String:
+		if runes {
+			return utf8.RuneCountInString(val.String()), nil
+		}
+
+	case reflect.Slice:
+		if runes && val.Type().Elem().Kind() == reflect.Uint8 {
+			return utf8.RuneCount(val.Bytes()), nil
+		}
+
+	case reflect.Array, reflect.Chan, reflect.Map:
+
+	default:
+		return 0, &LenError{Type: reflect.TypeOf(v)}
+	}
+
+	return val.Len(), nil
+}
+
+// elementsVal gets the elements of an array, a slice, or a pointer to them.
+//
+// nolint: exhaustive
+func elementsVal(v any) ([]any, bool) {
+	val := indirectVal(reflect.ValueOf(v))
+
+	switch val.Kind() {
+	case reflect.Array, reflect.Slice:
+		elems := make([]any, val.Len())
+
+		for i := range elems {
+			elems[i] = val.Index(i).Interface()
+		}
+
+		return elems, true
+	}
+
+	return nil, false
+}
+
+// indirectVal dereferences pointers and interfaces.
+func indirectVal(v reflect.Value) reflect.Value {
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return reflect.Value{}
+		}
+
+		v = v.Elem()
+	}
+
+	return v
+}
+
+// sortedMapKeys returns the keys of the map, sorted by their string representations.
+func sortedMapKeys(v reflect.Value) []reflect.Value {
+	keys := v.MapKeys()
+
+	sort.Slice(keys, func(i, j int) bool {
+		return fmt.Sprint(keys[i].Interface()) < fmt.Sprint(keys[j].Interface())
+	})
+
+	return keys
+}
+
 func ptr[T any](v T) *T {
 	return &v
 }
+
+// compareVal compares two values of the same kind. Numbers of different kinds are converted before being compared.
+// It returns false if the values are not comparable.
+//
+// nolint: exhaustive
+func compareVal(x, y any) (int, bool) {
+	vx, okx := orderedVal(x)
+	vy, oky := orderedVal(y)
+
+	if !okx || !oky {
+		return 0, false
+	}
+
+	switch {
+	case vx.Kind() == reflect.String && vy.Kind() == reflect.String:
+		return cmp.Compare(vx.String(), vy.String()), true
+
+	case vx.CanInt() && vy.CanInt():
+		return cmp.Compare(vx.Int(), vy.Int()), true
+
+	case vx.CanUint() && vy.CanUint():
+		return cmp.Compare(vx.Uint(), vy.Uint()), true
+
+	case vx.CanInt() && vy.CanUint():
+		if vx.Int() < 0 {
+			return -1, true
+		}
+
+		return cmp.Compare(uint64(vx.Int()), vy.Uint()), true
+
+	case vx.CanUint() && vy.CanInt():
+		if vy.Int() < 0 {
+			return 1, true
+		}
+
+		return cmp.Compare(vx.Uint(), uint64(vy.Int())), true
+
+	case isNumber(vx) && isNumber(vy):
+		fx, fy := floatVal(vx), floatVal(vy)
+
+		if math.IsNaN(fx) || math.IsNaN(fy) {
+			return 0, false
+		}
+
+		return cmp.Compare(fx, fy), true
+	}
+
+	return 0, false
+}
+
+// orderedVal dereferences the value and converts []byte and json.Number to their comparable forms.
+func orderedVal(v any) (reflect.Value, bool) {
+	switch val := v.(type) {
+	case nil:
+		return reflect.Value{}, false
+
+	case []byte:
+		return reflect.ValueOf(string(val)), true
+
+	case json.Number:
+		if i, err := val.Int64(); err == nil {
+			return reflect.ValueOf(i), true
+		}
+
+		f, err := val.Float64()
+
+		return reflect.ValueOf(f), err == nil
+	}
+
+	val := reflect.ValueOf(v)
+
+	if val.Kind() == reflect.Ptr {
+		if val.IsNil() {
+			return reflect.Value{}, false
+		}
+
+		return orderedVal(val.Elem().Interface())
+	}
+
+	return val, val.Kind() == reflect.String || isNumber(val)
+}
+
+func isNumber(v reflect.Value) bool {
+	return v.CanInt() || v.CanUint() || v.CanFloat()
+}
+
+func floatVal(v reflect.Value) float64 {
+	switch {
+	case v.CanInt():
+		return float64(v.Int())
+
+	case v.CanUint():
+		return float64(v.Uint())
+	}
+
+	return v.Float()
+}
@@ -1,9 +1,12 @@
 package matcher
 
 import (
+	"encoding/json"
 	"errors"
+	"math"
 	"regexp"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -44,6 +47,57 @@ func Test_strVal(t *testing.T) {
 	}
 }
 
+func Test_textVal(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		scenario string
+		input    any
+		expected *string
+	}{
+		{
+			scenario: "string",
+			input:    "foobar",
+			expected: ptr("foobar"),
+		},
+		{
+			scenario: "[]byte",
+			input:    []byte("foobar"),
+			expected: ptr("foobar"),
+		},
+		{
+			scenario: "fmt.Stringer",
+			input:    time.UTC,
+			expected: ptr("UTC"),
+		},
+		{
+			scenario: "error",
+			input:    errors.New("foobar"),
+			expected: ptr("foobar"),
+		},
+		{
+			scenario: "nil fmt.Stringer",
+			input:    (*time.Location)(nil),
+		},
+		{
+			scenario: "nil error",
+			input:    (*json.SyntaxError)(nil),
+		},
+		{
+			scenario: "not a string",
+			input:    42,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.scenario, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tc.expected, textVal(tc.input))
+		})
+	}
+}
+
 func Test_jsonVal(t *testing.T) {
 	t.Parallel()
 
@@ -244,3 +298,99 @@ func TestIsEmpty(t *testing.T) {
 		})
 	}
 }
+
+func Test_compareVal(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		scenario   string
+		x          any
+		y          any
+		expected   int
+		comparable bool
+	}{
+		{
+			scenario:   "int and int64",
+			x:          1,
+			y:          int64(2),
+			expected:   -1,
+			comparable: true,
+		},
+		{
+			scenario:   "negative int and uint",
+			x:          -1,
+			y:          uint(0),
+			expected:   -1,
+			comparable: true,
+		},
+		{
+			scenario:   "uint and negative int",
+			x:          uint(0),
+			y:          -1,
+			expected:   1,
+			comparable: true,
+		},
+		{
+			scenario:   "uint and int",
+			x:          uint(2),
+			y:          2,
+			comparable: true,
+		},
+		{
+			scenario:   "large uint64 and int64",
+			x:          uint64(math.MaxUint64),
+			y:          int64(math.MaxInt64),
+			expected:   1,
+			comparable: true,
+		},
+		{
+			scenario:   "int and float",
+			x:          2,
+			y:          1.5,
+			expected:   1,
+			comparable: true,
+		},
+		{
+			scenario:   "json number float",
+			x:          json.Number("1.5"),
+			y:          1.5,
+			comparable: true,
+		},
+		{
+			scenario: "invalid json number",
+			x:        json.Number("foo"),
+			y:        1.5,
+		},
+		{
+			scenario: "NaN",
+			x:        math.NaN(),
+			y:        1.5,
+		},
+		{
+			scenario: "nil pointer",
+			x:        (*int)(nil),
+			y:        1,
+		},
+		{
+			scenario: "string and int",
+			x:        "1",
+			y:        1,
+		},
+		{
+			scenario: "struct",
+			x:        struct{}{},
+			y:        struct{}{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.scenario, func(t *testing.T) {
+			t.Parallel()
+
+			result, ok := compareVal(tc.x, tc.y)
+
+			assert.Equal(t, tc.expected, result)
+			assert.Equal(t, tc.comparable, ok)
+		})
+	}
+}
@@ -0,0 +1,683 @@
+package matcher
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"strings"
+
+	"github.com/swaggest/assertjson"
+)
+
+var errJSONNotMatched = errors.New("embedded matcher does not match")
+
+// JSONOption configures the JSON matcher.
+type JSONOption func(c *jsonConfig)
+
+type jsonConfig struct {
+	ignoreArrayOrder  bool
+	ignoreExtraFields bool
+	ignorePaths       [][]jsonPathSegment
+	tolerance         *float64
+
+	// embedded is the decoded expectation whose matchers are kept as they are, or nil if it has no matchers.
+	embedded any
+}
+
+// IgnoreArrayOrder makes the JSON matcher compare the arrays regardless of the order of their elements.
+func IgnoreArrayOrder() JSONOption {
+	return func(c *jsonConfig) {
+		c.ignoreArrayOrder = true
+	}
+}
+
+// IgnoreExtraFields makes the JSON matcher ignore the fields of the actual objects that are not in the expectation, so
+// the expectation only has to be a subset of the actual. The elements of the arrays are still matched one by one.
+func IgnoreExtraFields() JSONOption {
+	return func(c *jsonConfig) {
+		c.ignoreExtraFields = true
+	}
+}
+
+// IgnorePaths makes the JSON matcher ignore the nodes at the paths, in both the expectation and the actual. See JSONPath
+// for the supported syntax. The option panics if a path is invalid.
+func IgnorePaths(paths ...string) JSONOption {
+	segments := make([][]jsonPathSegment, len(paths))
+
+	for i, p := range paths {
+		s, err := parseJSONPath(p)
+		if err != nil {
+			panic(err)
+		}
+
+		segments[i] = s
+	}
+
+	return func(c *jsonConfig) {
+		c.ignorePaths = append(c.ignorePaths, segments...)
+	}
+}
+
+// NumberTolerance makes the JSON matcher consider two numbers equal if their absolute difference is at most delta.
+func NumberTolerance(delta float64) JSONOption {
+	return func(c *jsonConfig) {
+		c.tolerance = &delta
+	}
+}
+
+func jsonOptions(opts []JSONOption) jsonConfig {
+	var c jsonConfig
+
+	for _, o := range opts {
+		o(&c)
+	}
+
+	return c
+}
+
+func (c jsonConfig) isDefault() bool {
+	return !c.ignoreArrayOrder && !c.ignoreExtraFields && len(c.ignorePaths) == 0 && c.tolerance == nil && c.embedded == nil
+}
+
+// compare returns the diff as an error if the documents are not equal according to the options. The err is returned if
+// an embedded matcher fails.
+func (c jsonConfig) compare(expected, actual []byte) (diff error, err error) {
+	if c.isDefault() {
+		return assertjson.FailNotEqual(expected, actual), nil
+	}
+
+	exp, act, ok := c.decode(expected, actual)
+	if !ok {
+		// Let assertjson report the error.
+		return assertjson.FailNotEqual(expected, actual), nil
+	}
+
+	act, err = c.normalize(exp, act)
+	if err != nil {
+		return nil, err
+	}
+
+	expected, _ = json.Marshal(jsonPlaceholders(exp)) //nolint: errcheck,errchkjson
+	actual, _ = json.Marshal(jsonPlaceholders(act))   //nolint: errcheck,errchkjson
+
+	if diff := assertjson.FailNotEqual(expected, actual); diff != nil {
+		return diff, nil
+	}
+
+	// The actual could be a string that looks like the placeholder of an embedded matcher that does not match.
+	if p := unmatchedJSONPath(exp, act, "$"); p != "" {
+		return fmt.Errorf("%w at %s", errJSONNotMatched, p), nil
+	}
+
+	return nil, nil
+}
+
+// decode decodes the documents and removes the ignored paths. The expectation with the embedded matchers is used
+// instead of the expected document, if any. The ok flag is false if a document is not a valid JSON.
+func (c jsonConfig) decode(expected, actual []byte) (exp, act any, ok bool) {
+	if c.embedded != nil {
+		exp = c.embedded
+	} else if json.Unmarshal(expected, &exp) != nil {
+		return nil, nil, false
+	}
+
+	if json.Unmarshal(actual, &act) != nil {
+		return nil, nil, false
+	}
+
+	for _, p := range c.ignorePaths {
+		exp = deleteJSONPath(exp, p)
+		act = deleteJSONPath(act, p)
+	}
+
+	return exp, act, true
+}
+
+// normalize rewrites the actual so that the differences allowed by the options disappear: the extra fields are removed,
+// the array elements are reordered to face their matching expectations, the numbers within the tolerance are replaced
+// by the expected ones, and so are the values matched by the embedded matchers or by "<ignore-diff>".
+func (c jsonConfig) normalize(expected, actual any) (any, error) {
+	switch exp := expected.(type) {
+	case Matcher:
+		matched, err := exp.Match(actual)
+		if err != nil {
+			return nil, err
+		}
+
+		if matched {
+			return exp, nil
+		}
+
+	case map[string]any:
+		act, ok := actual.(map[string]any)
+		if !ok {
+			return actual, nil
+		}
+
+		result := make(map[string]any, len(act))
+
+		for k, v := range act {
+			e, ok := exp[k]
+
+			switch {
+			case ok:
+				n, err := c.normalize(e, v)
+				if err != nil {
+					return nil, err
+				}
+
+				result[k] = n
+
+			case !c.ignoreExtraFields:
+				result[k] = v
+			}
+		}
+
+		return result, nil
+
+	case []any:
+		act, ok := actual.([]any)
+		if !ok {
+			return actual, nil
+		}
+
+		if c.ignoreArrayOrder {
+			var err error
+
+			if act, err = c.reorder(exp, act); err != nil {
+				return nil, err
+			}
+		}
+
+		result := make([]any, len(act))
+
+		for i, v := range act {
+			if i < len(exp) {
+				var err error
+
+				if v, err = c.normalize(exp[i], v); err != nil {
+					return nil, err
+				}
+			}
+
+			result[i] = v
+		}
+
+		return result, nil
+
+	case float64:
+		if act, ok := actual.(float64); ok && c.tolerance != nil && math.Abs(exp-act) <= *c.tolerance {
+			return exp, nil
+		}
+
+	case string:
+		if exp == assertjson.IgnoreDiff {
+			return exp, nil
+		}
+	}
+
+	return actual, nil
+}
+
+// reorder moves the actual elements to the positions of the expectations they match. The elements without a match keep
+// their relative order and fill the remaining positions.
+func (c jsonConfig) reorder(expected, actual []any) ([]any, error) {
+	candidates := make([][]int, len(expected))
+
+	for i, e := range expected {
+		for j, a := range actual {
+			ok, err := c.equal(e, a)
+			if err != nil {
+				return nil, err
+			}
+
+			if ok {
+				candidates[i] = append(candidates[i], j)
+			}
+		}
+	}
+
+	result := make([]any, len(actual))
+	filled := make([]bool, len(actual))
+	used := make([]bool, len(actual))
+
+	for i, j := range pairElements(candidates, len(actual)) {
+		if j >= 0 && i < len(actual) {
+			result[i], filled[i], used[j] = actual[j], true, true
+		}
+	}
+
+	k := 0
+
+	for j, a := range actual {
+		if used[j] {
+			continue
+		}
+
+		for filled[k] {
+			k++
+		}
+
+		result[k], filled[k] = a, true
+	}
+
+	return result, nil
+}
+
+// equal determines if the actual equals the expectation according to the options.
+func (c jsonConfig) equal(expected, actual any) (bool, error) {
+	actual, err := c.normalize(expected, actual)
+	if err != nil {
+		return false, err
+	}
+
+	exp, _ := json.Marshal(jsonPlaceholders(expected)) //nolint: errcheck,errchkjson
+	act, _ := json.Marshal(jsonPlaceholders(actual))   //nolint: errcheck,errchkjson
+
+	return assertjson.FailNotEqual(exp, act) == nil && unmatchedJSONPath(expected, actual, "$") == "", nil
+}
+
+// unmatchedJSONPath returns the path of the first embedded matcher that does not match, or an empty string if all of
+// them match. The normalized actual has the matched ones in place of the values.
+func unmatchedJSONPath(expected, actual any, path string) string {
+	switch exp := expected.(type) {
+	case Matcher:
+		if _, ok := actual.(Matcher); !ok {
+			return path
+		}
+
+	case map[string]any:
+		act, _ := actual.(map[string]any) //nolint: errcheck
+
+		for _, k := range sortedJSONKeys(exp) {
+			if p := unmatchedJSONPath(exp[k], act[k], path+jsonPathSegment{key: k}.String()); p != "" {
+				return p
+			}
+		}
+
+	case []any:
+		act, _ := actual.([]any) //nolint: errcheck
+
+		for i, e := range exp {
+			var a any
+
+			if i < len(act) {
+				a = act[i]
+			}
+
+			if p := unmatchedJSONPath(e, a, path+jsonPathSegment{selector: jsonPathIndex, index: i}.String()); p != "" {
+				return p
+			}
+		}
+	}
+
+	return ""
+}
+
+// deleteJSONPath returns a copy of the node without the descendants at the path. The root path removes the whole node.
+func deleteJSONPath(node any, segments []jsonPathSegment) any {
+	if len(segments) == 0 {
+		return nil
+	}
+
+	s, rest := segments[0], segments[1:]
+
+	if s.recursive {
+		flat := s
+		flat.recursive = false
+
+		node = deleteJSONPath(node, append([]jsonPathSegment{flat}, rest...))
+
+		return mapJSONChildren(node, func(child any) any {
+			return deleteJSONPath(child, segments)
+		})
+	}
+
+	switch v := node.(type) {
+	case map[string]any:
+		result := make(map[string]any, len(v))
+
+		for k, child := range v {
+			switch {
+			case s.selector == jsonPathIndex || (s.selector == jsonPathKey && s.key != k):
+				result[k] = child
+
+			case len(rest) > 0:
+				result[k] = deleteJSONPath(child, rest)
+			}
+		}
+
+		return result
+
+	case []any:
+		result := make([]any, 0, len(v))
+
+		idx := s.index
+		if idx < 0 {
+			idx += len(v)
+		}
+
+		for i, child := range v {
+			switch {
+			case s.selector == jsonPathKey || (s.selector == jsonPathIndex && idx != i):
+				result = append(result, child)
+
+			case len(rest) > 0:
+				result = append(result, deleteJSONPath(child, rest))
+			}
+		}
+
+		return result
+	}
+
+	return node
+}
+
+// mapJSONChildren returns a copy of the node whose children are transformed.
+func mapJSONChildren(node any, fn func(child any) any) any {
+	switch v := node.(type) {
+	case map[string]any:
+		result := make(map[string]any, len(v))
+
+		for k, child := range v {
+			result[k] = fn(child)
+		}
+
+		return result
+
+	case []any:
+		result := make([]any, len(v))
+
+		for i, child := range v {
+			result[i] = fn(child)
+		}
+
+		return result
+	}
+
+	return node
+}
+
+var (
+	matcherType       = reflect.TypeOf((*Matcher)(nil)).Elem()
+	jsonMarshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+)
+
+// embedJSONMatchers decodes the expectation as JSON, but keeps the matchers inside it as they are. The ok flag is false
+// if the expectation has no matchers.
+func embedJSONMatchers(v any) (_ any, ok bool, _ error) {
+	switch v.(type) {
+	case string, []byte:
+		return nil, false, nil
+	}
+
+	val := reflect.ValueOf(v)
+	if !hasJSONMatcher(val) {
+		return nil, false, nil
+	}
+
+	decoded, err := decodeJSONMatchers(embedJSONMatcher(val))
+	if err != nil {
+		return nil, false, err
+	}
+
+	return decoded, true, nil
+}
+
+// jsonMatcherVal returns the matcher if the value is a non-nil matcher.
+func jsonMatcherVal(v reflect.Value) (Matcher, bool) {
+	if !v.IsValid() || !v.CanInterface() || !v.Type().Implements(matcherType) {
+		return nil, false
+	}
+
+	if (v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface) && v.IsNil() {
+		return nil, false
+	}
+
+	return v.Interface().(Matcher), true //nolint: forcetypeassert
+}
+
+// hasJSONMatcher determines if the value contains a matcher, without looking into the values that marshal themselves.
+//
+// nolint: exhaustive
+func hasJSONMatcher(v reflect.Value) bool {
+	if _, ok := jsonMatcherVal(v); ok {
+		return true
+	}
+
+	if !v.IsValid() || v.Type().Implements(jsonMarshalerType) || v.Type().Implements(textMarshalerType) {
+		return false
+	}
+
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		return !v.IsNil() && hasJSONMatcher(v.Elem())
+
+	case reflect.Map:
+		for _, k := range v.MapKeys() {
+			if hasJSONMatcher(v.MapIndex(k)) {
+				return true
+			}
+		}
+
+	case reflect.Slice, reflect.Array:
+		for i := range v.Len() {
+			if hasJSONMatcher(v.Index(i)) {
+				return true
+			}
+		}
+
+	case reflect.Struct:
+		for i := range v.NumField() {
+			if v.Type().Field(i).IsExported() && hasJSONMatcher(v.Field(i)) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// embedJSONMatcher converts the value to a JSON-like structure that keeps the matchers. The values without matchers are
+// kept as they are.
+//
+// nolint: exhaustive
+func embedJSONMatcher(v reflect.Value) any {
+	if m, ok := jsonMatcherVal(v); ok {
+		return m
+	}
+
+	if !hasJSONMatcher(v) {
+		return v.Interface()
+	}
+
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		return embedJSONMatcher(v.Elem())
+
+	case reflect.Map:
+		result := make(map[string]any, v.Len())
+
+		for _, k := range v.MapKeys() {
+			result[jsonMapKey(k)] = embedJSONMatcher(v.MapIndex(k))
+		}
+
+		return result
+
+	case reflect.Slice, reflect.Array:
+		result := make([]any, v.Len())
+
+		for i := range v.Len() {
+			result[i] = embedJSONMatcher(v.Index(i))
+		}
+
+		return result
+	}
+
+	result := make(map[string]any)
+
+	embedJSONStruct(v, result)
+
+	return result
+}
+
+// embedJSONStruct sets the fields of the struct to the result, following the rules of encoding/json for the tags and
+// the embedded structs. The fields of the outer struct take precedence over the promoted ones.
+func embedJSONStruct(v reflect.Value, result map[string]any) {
+	promoted := make(map[string]any)
+
+	for i := range v.NumField() {
+		f := v.Type().Field(i)
+
+		tag := f.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+
+		name, opts, _ := strings.Cut(tag, ",")
+		fv := v.Field(i)
+
+		if f.Anonymous && name == "" {
+			if fv.Kind() == reflect.Ptr {
+				if fv.IsNil() {
+					continue
+				}
+
+				fv = fv.Elem()
+			}
+
+			if fv.Kind() == reflect.Struct {
+				embedJSONStruct(fv, promoted)
+
+				continue
+			}
+		}
+
+		if !f.IsExported() {
+			continue
+		}
+
+		if strings.Contains(","+opts+",", ",omitempty,") && isEmptyJSONValue(fv) {
+			continue
+		}
+
+		if name == "" {
+			name = f.Name
+		}
+
+		result[name] = embedJSONMatcher(fv)
+	}
+
+	for k, p := range promoted {
+		if _, ok := result[k]; !ok {
+			result[k] = p
+		}
+	}
+}
+
+// nolint: exhaustive
+func isEmptyJSONValue(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
+		return v.Len() == 0
+
+	case reflect.Struct:
+		return false
+	}
+
+	return v.IsZero()
+}
+
+func jsonMapKey(k reflect.Value) string {
+	if k.Kind() == reflect.String {
+		return k.String()
+	}
+
+	if t, ok := k.Interface().(encoding.TextMarshaler); ok {
+		if b, err := t.MarshalText(); err == nil {
+			return string(b)
+		}
+	}
+
+	return fmt.Sprint(k.Interface())
+}
+
+// decodeJSONMatchers decodes the values of a structure built by embedJSONMatcher, so that it looks like a document
+// decoded by encoding/json, except for the matchers.
+func decodeJSONMatchers(v any) (any, error) {
+	switch v := v.(type) {
+	case Matcher:
+		return v, nil
+
+	case map[string]any:
+		result := make(map[string]any, len(v))
+
+		for k, child := range v {
+			decoded, err := decodeJSONMatchers(child)
+			if err != nil {
+				return nil, err
+			}
+
+			result[k] = decoded
+		}
+
+		return result, nil
+
+	case []any:
+		result := make([]any, len(v))
+
+		for i, child := range v {
+			decoded, err := decodeJSONMatchers(child)
+			if err != nil {
+				return nil, err
+			}
+
+			result[i] = decoded
+		}
+
+		return result, nil
+	}
+
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	var result any
+
+	if err := json.Unmarshal(b, &result); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// jsonPlaceholders returns a copy of the decoded document whose matchers are replaced by "<expectation>", for the
+// messages and the diffs.
+func jsonPlaceholders(v any) any {
+	if m, ok := v.(Matcher); ok {
+		return "<" + m.Expected() + ">"
+	}
+
+	return mapJSONChildren(v, jsonPlaceholders)
+}
+
+// marshalJSONPlaceholders marshals the value without escaping the HTML characters, so that "<" and ">" of the
+// placeholders are kept.
+func marshalJSONPlaceholders(v any) ([]byte, error) {
+	var buf bytes.Buffer
+
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+
+	if err := enc.Encode(v); err != nil {
+		return nil, err
+	}
+
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n")), nil
+}
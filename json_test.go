@@ -0,0 +1,365 @@
+package matcher_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.nhat.io/matcher/v3"
+)
+
+func TestJSON_Options(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		scenario string
+		matcher  matcher.Matcher
+		actual   string
+		expected bool
+	}{
+		{
+			scenario: "no option - extra field",
+			matcher:  matcher.JSON(`{"id": 1}`),
+			actual:   `{"id": 1, "name": "John"}`,
+		},
+		{
+			scenario: "ignore extra fields",
+			matcher:  matcher.JSON(`{"id": 1, "user": {"name": "John"}}`, matcher.IgnoreExtraFields()),
+			actual:   `{"id": 1, "created": "today", "user": {"name": "John", "age": 42}}`,
+			expected: true,
+		},
+		{
+			scenario: "ignore extra fields - missing field",
+			matcher:  matcher.JSON(`{"id": 1, "name": "John"}`, matcher.IgnoreExtraFields()),
+			actual:   `{"id": 1, "age": 42}`,
+		},
+		{
+			scenario: "ignore extra fields - in arrays",
+			matcher:  matcher.JSON(`[{"id": 1}, {"id": 2}]`, matcher.IgnoreExtraFields()),
+			actual:   `[{"id": 1, "name": "a"}, {"id": 2, "name": "b"}]`,
+			expected: true,
+		},
+		{
+			scenario: "ignore extra fields - extra elements",
+			matcher:  matcher.JSON(`[1, 2]`, matcher.IgnoreExtraFields()),
+			actual:   `[1, 2, 3]`,
+		},
+		{
+			scenario: "ignore extra fields - ignore diff",
+			matcher:  matcher.JSON(`{"id": "<ignore-diff>"}`, matcher.IgnoreExtraFields()),
+			actual:   `{"id": 42, "name": "John"}`,
+			expected: true,
+		},
+		{
+			scenario: "no option - array order",
+			matcher:  matcher.JSON(`[1, 2, 3]`),
+			actual:   `[3, 1, 2]`,
+		},
+		{
+			scenario: "ignore array order",
+			matcher:  matcher.JSON(`{"ids": [1, 2, 3]}`, matcher.IgnoreArrayOrder()),
+			actual:   `{"ids": [3, 1, 2]}`,
+			expected: true,
+		},
+		{
+			scenario: "ignore array order - mismatched",
+			matcher:  matcher.JSON(`[1, 2, 3]`, matcher.IgnoreArrayOrder()),
+			actual:   `[3, 1, 4]`,
+		},
+		{
+			scenario: "ignore array order - duplicates",
+			matcher:  matcher.JSON(`[1, 1, 2]`, matcher.IgnoreArrayOrder()),
+			actual:   `[1, 2, 2]`,
+		},
+		{
+			scenario: "ignore array order - nested",
+			matcher:  matcher.JSON(`[{"tags": ["a", "b"]}, {"tags": ["c"]}]`, matcher.IgnoreArrayOrder()),
+			actual:   `[{"tags": ["c"]}, {"tags": ["b", "a"]}]`,
+			expected: true,
+		},
+		{
+			scenario: "ignore array order - with extra fields",
+			matcher:  matcher.JSON(`[{"id": 1}, {"id": 2}]`, matcher.IgnoreArrayOrder(), matcher.IgnoreExtraFields()),
+			actual:   `[{"id": 2, "name": "b"}, {"id": 1, "name": "a"}]`,
+			expected: true,
+		},
+		{
+			scenario: "ignore array order - ignore diff",
+			matcher:  matcher.JSON(`[{"id": 1, "at": "<ignore-diff>"}, {"id": 2, "at": "<ignore-diff>"}]`, matcher.IgnoreArrayOrder()),
+			actual:   `[{"id": 2, "at": "today"}, {"id": 1, "at": "yesterday"}]`,
+			expected: true,
+		},
+		{
+			scenario: "ignore paths",
+			matcher:  matcher.JSON(`{"id": 1, "updated_at": "yesterday"}`, matcher.IgnorePaths("$.updated_at", "$.created_at")),
+			actual:   `{"id": 1, "created_at": "today", "updated_at": "today"}`,
+			expected: true,
+		},
+		{
+			scenario: "ignore paths - mismatched",
+			matcher:  matcher.JSON(`{"id": 1}`, matcher.IgnorePaths("$.updated_at")),
+			actual:   `{"id": 2, "updated_at": "today"}`,
+		},
+		{
+			scenario: "ignore paths - wildcard",
+			matcher:  matcher.JSON(`{"items": [{"id": 1}, {"id": 2}]}`, matcher.IgnorePaths("$.items[*].etag")),
+			actual:   `{"items": [{"id": 1, "etag": "a"}, {"id": 2, "etag": "b"}]}`,
+			expected: true,
+		},
+		{
+			scenario: "ignore paths - recursive descent",
+			matcher:  matcher.JSON(`{"id": 1, "user": {"name": "John"}}`, matcher.IgnorePaths("$..etag")),
+			actual:   `{"id": 1, "etag": "a", "user": {"name": "John", "etag": "b"}}`,
+			expected: true,
+		},
+		{
+			scenario: "ignore paths - index",
+			matcher:  matcher.JSON(`[1, 3]`, matcher.IgnorePaths("$[1]")),
+			actual:   `[1, 2, 3]`,
+		},
+		{
+			scenario: "ignore paths - root",
+			matcher:  matcher.JSON(`{"id": 1}`, matcher.IgnorePaths("$")),
+			actual:   `[]`,
+			expected: true,
+		},
+		{
+			scenario: "no option - number",
+			matcher:  matcher.JSON(`{"price": 9.99}`),
+			actual:   `{"price": 9.990001}`,
+		},
+		{
+			scenario: "number tolerance",
+			matcher:  matcher.JSON(`{"prices": [9.99, 10]}`, matcher.NumberTolerance(0.01)),
+			actual:   `{"prices": [9.990001, 9.995]}`,
+			expected: true,
+		},
+		{
+			scenario: "number tolerance - exceeded",
+			matcher:  matcher.JSON(`{"price": 9.99}`, matcher.NumberTolerance(0.001)),
+			actual:   `{"price": 10}`,
+		},
+		{
+			scenario: "number tolerance - zero",
+			matcher:  matcher.JSON(`1`, matcher.NumberTolerance(0)),
+			actual:   `1.0`,
+			expected: true,
+		},
+		{
+			scenario: "invalid actual",
+			matcher:  matcher.JSON(`{}`, matcher.IgnoreExtraFields()),
+			actual:   `{`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.scenario, func(t *testing.T) {
+			t.Parallel()
+
+			result, err := tc.matcher.Match(tc.actual)
+
+			assert.Equal(t, tc.expected, result)
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestJSON_Options_Explain(t *testing.T) {
+	t.Parallel()
+
+	m := matcher.JSON(`{"id": 1, "ids": [1, 2]}`, matcher.IgnoreExtraFields(), matcher.IgnoreArrayOrder())
+	actual := matcher.Explain(m, `{"id": 2, "ids": [2, 1], "name": "John"}`)
+
+	expected := `expected {"id": 1, "ids": [1, 2]}, not equal:
+ {
+-  "id": 1,
++  "id": 2,
+   "ids": [
+     1,
+     2
+   ]
+ }
+`
+
+	require.NotNil(t, actual)
+	assert.Equal(t, expected, actual.String())
+}
+
+func TestIgnorePaths_InvalidPath(t *testing.T) {
+	t.Parallel()
+
+	assert.PanicsWithError(t, `invalid json path "id": must start with $`, func() {
+		matcher.IgnorePaths("id")
+	})
+}
+
+type jsonTestUser struct {
+	jsonTestBase
+
+	Name     any    `json:"name"`
+	Email    string `json:"email,omitempty"`
+	Password string `json:"-"`
+	Age      any
+}
+
+type jsonTestBase struct {
+	ID any `json:"id"`
+}
+
+func TestJSON_EmbeddedMatchers(t *testing.T) {
+	t.Parallel()
+
+	const actual = `{"id": "3f2504e0-4f89-11d3-9a0c-0305e82c3301", "name": "John", "createdAt": "2020-01-02", "tags": ["a", "b"], "score": 9.5}`
+
+	testCases := []struct {
+		scenario string
+		matcher  matcher.Matcher
+		actual   string
+		expected bool
+	}{
+		{
+			scenario: "map",
+			matcher: matcher.JSON(map[string]any{
+				"id":        matcher.Regex("^[0-9a-f-]{36}$"),
+				"name":      "John",
+				"createdAt": matcher.IsNotEmpty(),
+				"tags":      []any{"a", matcher.Any},
+				"score":     matcher.GreaterThan(9),
+			}),
+			actual:   actual,
+			expected: true,
+		},
+		{
+			scenario: "map - mismatched",
+			matcher: matcher.JSON(map[string]any{
+				"id":        matcher.Regex("^[0-9]+$"),
+				"name":      "John",
+				"createdAt": matcher.IsNotEmpty(),
+				"tags":      []any{"a", matcher.Any},
+				"score":     matcher.GreaterThan(9),
+			}),
+			actual: actual,
+		},
+		{
+			scenario: "with options",
+			matcher: matcher.JSON(map[string]any{
+				"id":   matcher.Len(36),
+				"tags": []any{"b", matcher.HasPrefix("a")},
+			}, matcher.IgnoreExtraFields(), matcher.IgnoreArrayOrder()),
+			actual:   actual,
+			expected: true,
+		},
+		{
+			scenario: "struct",
+			matcher: matcher.JSON(jsonTestUser{
+				jsonTestBase: jsonTestBase{ID: matcher.Len(36)},
+				Name:         matcher.Regex("^J"),
+				Password:     "secret",
+				Age:          matcher.GreaterOrEqual(18),
+			}),
+			actual:   `{"id": "3f2504e0-4f89-11d3-9a0c-0305e82c3301", "name": "John", "Age": 20}`,
+			expected: true,
+		},
+		{
+			scenario: "pointer",
+			matcher:  matcher.JSON(&map[int]any{1: matcher.Any}),
+			actual:   `{"1": null}`,
+			expected: true,
+		},
+		{
+			scenario: "root",
+			matcher:  matcher.JSON(matcher.HasKey("id")),
+			actual:   actual,
+			expected: true,
+		},
+		{
+			scenario: "no matcher",
+			matcher:  matcher.JSON(map[string]any{"id": 1}),
+			actual:   `{"id": 1}`,
+			expected: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.scenario, func(t *testing.T) {
+			t.Parallel()
+
+			result, err := tc.matcher.Match(tc.actual)
+
+			assert.Equal(t, tc.expected, result)
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestJSON_EmbeddedMatchers_Expected(t *testing.T) {
+	t.Parallel()
+
+	m := matcher.JSON(map[string]any{
+		"id":   matcher.Regex("^[0-9]+$"),
+		"name": matcher.Regex("^[0-9]+$"),
+	})
+
+	assert.Equal(t, `{"id":"<^[0-9]+$>","name":"<^[0-9]+$>"}`, m.Expected())
+}
+
+func TestJSON_EmbeddedMatchers_PlaceholderLiteral(t *testing.T) {
+	t.Parallel()
+
+	m := matcher.JSON(map[string]any{"id": matcher.Equal("x"), "name": "<x>"})
+
+	result, err := m.Match(`{"id": "x", "name": "x"}`)
+
+	assert.False(t, result)
+	require.NoError(t, err)
+
+	result, err = m.Match(`{"id": "x", "name": "<x>"}`)
+
+	assert.True(t, result)
+	require.NoError(t, err)
+
+	result, err = m.Match(`{"id": "<x>", "name": "<x>"}`)
+
+	assert.False(t, result)
+	require.NoError(t, err)
+
+	actual := matcher.Explain(m, `{"id": "<x>", "name": "<x>"}`)
+
+	require.NotNil(t, actual)
+	assert.Equal(t, `expected {"id":"<x>","name":"<x>"}, embedded matcher does not match at $.id`, actual.String())
+}
+
+func TestJSON_EmbeddedMatchers_Explain(t *testing.T) {
+	t.Parallel()
+
+	m := matcher.JSON(map[string]any{"id": matcher.GreaterThan(10), "name": "John"})
+	actual := matcher.Explain(m, `{"id": 5, "name": "John"}`)
+
+	expected := `expected {"id":"<> 10>","name":"John"}, not equal:
+ {
+-  "id": "<> 10>",
++  "id": 5,
+   "name": "John"
+ }
+`
+
+	require.NotNil(t, actual)
+	assert.Equal(t, expected, actual.String())
+}
+
+func TestJSON_EmbeddedMatchers_Error(t *testing.T) {
+	t.Parallel()
+
+	m := matcher.JSON(map[string]any{"id": matcher.Len(1)})
+
+	result, err := m.Match(`{"id": 5}`)
+
+	assert.False(t, result)
+	require.EqualError(t, err, `len: value of type float64 does not have a length`)
+
+	actual := matcher.Explain(m, `{"id": 5}`)
+
+	require.NotNil(t, actual)
+	assert.Equal(t, `expected {"id":"<len is 1>"}, error: len: value of type float64 does not have a length`, actual.String())
+}
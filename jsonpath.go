@@ -0,0 +1,557 @@
+package matcher
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+var (
+	errJSONPathNotFound = errors.New("path not found")
+	errInvalidJSONPath  = errors.New("invalid json path")
+	errInvalidPointer   = errors.New("invalid json pointer")
+)
+
+const (
+	jsonPathKey jsonPathSelector = iota
+	jsonPathIndex
+	jsonPathWildcard
+)
+
+type jsonPathSelector int
+
+// jsonPathSegment is a segment of a JSONPath expression, like .name, [0], [*] or ..name.
+type jsonPathSegment struct {
+	selector  jsonPathSelector
+	key       string
+	index     int
+	recursive bool
+}
+
+func (s jsonPathSegment) String() string {
+	var prefix string
+
+	if s.recursive {
+		prefix = ".."
+	}
+
+	switch s.selector {
+	case jsonPathIndex:
+		return fmt.Sprintf("%s[%d]", prefix, s.index)
+
+	case jsonPathWildcard:
+		return prefix + "[*]"
+	}
+
+	if jsonPathName.MatchString(s.key) {
+		return prefix + "." + s.key
+	}
+
+	return fmt.Sprintf("%s[%q]", prefix, s.key)
+}
+
+var jsonPathName = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// parseJSONPath parses a JSONPath expression. The supported syntax is the root $, the child .name or ['name'], the
+// index [0] or [-1], the wildcard .* or [*], and the recursive descent ..name.
+func parseJSONPath(path string) ([]jsonPathSegment, error) {
+	if !strings.HasPrefix(path, "$") {
+		return nil, fmt.Errorf("%w %q: must start with $", errInvalidJSONPath, path)
+	}
+
+	var segments []jsonPathSegment
+
+	for i := 1; i < len(path); {
+		var seg jsonPathSegment
+
+		switch {
+		case strings.HasPrefix(path[i:], ".."):
+			seg.recursive = true
+			i += 2
+
+			if i < len(path) && path[i] == '[' {
+				break
+			}
+
+			fallthrough
+
+		case path[i] == '.':
+			if !seg.recursive {
+				i++
+			}
+
+			end := i + strings.IndexAny(path[i:]+".", ".[")
+			if end == i {
+				return nil, fmt.Errorf("%w %q: missing name at offset %d", errInvalidJSONPath, path, i)
+			}
+
+			if name := path[i:end]; name == "*" {
+				seg.selector = jsonPathWildcard
+			} else {
+				seg.key = name
+			}
+
+			segments = append(segments, seg)
+			i = end
+
+			continue
+
+		case path[i] != '[':
+			return nil, fmt.Errorf("%w %q: unexpected %q at offset %d", errInvalidJSONPath, path, path[i], i)
+		}
+
+		end, err := parseJSONPathBracket(path, i, &seg)
+		if err != nil {
+			return nil, err
+		}
+
+		segments = append(segments, seg)
+		i = end
+	}
+
+	return segments, nil
+}
+
+// parseJSONPathBracket parses a bracket selector that starts at the offset, and returns the offset after it.
+func parseJSONPathBracket(path string, offset int, seg *jsonPathSegment) (int, error) {
+	i := offset + 1
+
+	switch {
+	case strings.HasPrefix(path[i:], "*]"):
+		seg.selector = jsonPathWildcard
+
+		return i + 2, nil
+
+	case i < len(path) && (path[i] == '\'' || path[i] == '"'):
+		quote := path[i]
+
+		var sb strings.Builder
+
+		for i++; i < len(path); i++ {
+			switch c := path[i]; {
+			case c == '\\' && i+1 < len(path):
+				i++
+				sb.WriteByte(path[i])
+
+			case c == quote:
+				if i+1 >= len(path) || path[i+1] != ']' {
+					return 0, fmt.Errorf("%w %q: missing ] at offset %d", errInvalidJSONPath, path, i+1)
+				}
+
+				seg.key = sb.String()
+
+				return i + 2, nil
+
+			default:
+				sb.WriteByte(c)
+			}
+		}
+
+		return 0, fmt.Errorf("%w %q: unterminated string at offset %d", errInvalidJSONPath, path, offset+1)
+	}
+
+	end := strings.IndexByte(path[i:], ']')
+	if end < 0 {
+		return 0, fmt.Errorf("%w %q: missing ] at offset %d", errInvalidJSONPath, path, offset)
+	}
+
+	idx, err := strconv.Atoi(path[i : i+end])
+	if err != nil {
+		return 0, fmt.Errorf("%w %q: invalid index at offset %d", errInvalidJSONPath, path, i)
+	}
+
+	seg.selector = jsonPathIndex
+	seg.index = idx
+
+	return i + end + 1, nil
+}
+
+// isDefiniteJSONPath determines if the path selects at most one node.
+func isDefiniteJSONPath(segments []jsonPathSegment) bool {
+	for _, s := range segments {
+		if s.recursive || s.selector == jsonPathWildcard {
+			return false
+		}
+	}
+
+	return true
+}
+
+// findJSONPath finds the node of a definite path, or all the nodes of an indefinite path as a []any.
+func findJSONPath(doc any, segments []jsonPathSegment) (any, error) {
+	if !isDefiniteJSONPath(segments) {
+		nodes := []any{doc}
+
+		for _, s := range segments {
+			nodes = selectJSONPath(nodes, s)
+		}
+
+		return nodes, nil
+	}
+
+	node := doc
+	path := "$"
+
+	for _, s := range segments {
+		next := selectJSONPath([]any{node}, s)
+		if len(next) == 0 {
+			key := s.key
+			if s.selector == jsonPathIndex {
+				key = strconv.Itoa(s.index)
+			}
+
+			return nil, fmt.Errorf("%w: %s", errJSONPathNotFound, jsonNodeNotFound(node, path, key, s.selector))
+		}
+
+		node = next[0]
+		path += s.String()
+	}
+
+	return node, nil
+}
+
+// selectJSONPath applies a segment to the nodes.
+func selectJSONPath(nodes []any, s jsonPathSegment) []any {
+	if s.recursive {
+		var descendants []any
+
+		for _, n := range nodes {
+			descendants = jsonDescendants(descendants, n)
+		}
+
+		nodes = descendants
+	}
+
+	var result []any
+
+	for _, n := range nodes {
+		switch v := n.(type) {
+		case map[string]any:
+			switch s.selector {
+			case jsonPathKey:
+				if c, ok := v[s.key]; ok {
+					result = append(result, c)
+				}
+
+			case jsonPathWildcard:
+				for _, k := range sortedJSONKeys(v) {
+					result = append(result, v[k])
+				}
+			}
+
+		case []any:
+			switch s.selector {
+			case jsonPathIndex:
+				idx := s.index
+				if idx < 0 {
+					idx += len(v)
+				}
+
+				if idx >= 0 && idx < len(v) {
+					result = append(result, v[idx])
+				}
+
+			case jsonPathWildcard:
+				result = append(result, v...)
+			}
+		}
+	}
+
+	return result
+}
+
+// jsonDescendants appends the node and all its descendants, in document order.
+func jsonDescendants(result []any, node any) []any {
+	result = append(result, node)
+
+	switch v := node.(type) {
+	case map[string]any:
+		for _, k := range sortedJSONKeys(v) {
+			result = jsonDescendants(result, v[k])
+		}
+
+	case []any:
+		for _, c := range v {
+			result = jsonDescendants(result, c)
+		}
+	}
+
+	return result
+}
+
+func sortedJSONKeys(m map[string]any) []string {
+	keys := make([]string, 0, len(m))
+
+	for k := range m {
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
+
+// parseJSONPointer parses a JSON Pointer, as defined in RFC 6901.
+func parseJSONPointer(pointer string) ([]string, error) {
+	if pointer == "" {
+		return nil, nil
+	}
+
+	if !strings.HasPrefix(pointer, "/") {
+		return nil, fmt.Errorf("%w %q: must be empty or start with /", errInvalidPointer, pointer)
+	}
+
+	tokens := strings.Split(pointer[1:], "/")
+
+	for i, t := range tokens {
+		tokens[i] = strings.NewReplacer("~1", "/", "~0", "~").Replace(t)
+	}
+
+	return tokens, nil
+}
+
+var jsonPointerIndex = regexp.MustCompile(`^(0|[1-9][0-9]*)$`)
+
+// findJSONPointer finds the node referenced by the tokens of a JSON Pointer.
+func findJSONPointer(doc any, tokens []string) (any, error) {
+	node := doc
+	path := ""
+
+	for _, t := range tokens {
+		var (
+			next  any
+			found bool
+		)
+
+		switch v := node.(type) {
+		case map[string]any:
+			next, found = v[t]
+
+		case []any:
+			if jsonPointerIndex.MatchString(t) {
+				if idx, err := strconv.Atoi(t); err == nil && idx < len(v) {
+					next, found = v[idx], true
+				}
+			}
+		}
+
+		if !found {
+			return nil, fmt.Errorf("%w: %s", errJSONPathNotFound, jsonNodeNotFound(node, strconv.Quote(path), t, jsonPathWildcard))
+		}
+
+		node = next
+		path += "/" + strings.NewReplacer("~", "~0", "/", "~1").Replace(t)
+	}
+
+	return node, nil
+}
+
+// jsonNodeNotFound describes why the child of a node could not be found. The selector is jsonPathKey or jsonPathIndex
+// for a JSONPath, and jsonPathWildcard for a JSON Pointer whose token could be both.
+func jsonNodeNotFound(node any, path, key string, selector jsonPathSelector) string {
+	switch v := node.(type) {
+	case map[string]any:
+		if selector != jsonPathIndex {
+			return fmt.Sprintf("no key %q at %s", key, path)
+		}
+
+		return fmt.Sprintf("got object at %s, not an array", path)
+
+	case []any:
+		switch {
+		case selector == jsonPathKey:
+			return fmt.Sprintf("got array at %s, not an object", path)
+
+		case selector == jsonPathWildcard && !jsonPointerIndex.MatchString(key):
+			return fmt.Sprintf("invalid index %q at %s", key, path)
+		}
+
+		return fmt.Sprintf("index %s out of range at %s, len %d", key, path, len(v))
+	}
+
+	switch selector {
+	case jsonPathKey:
+		return fmt.Sprintf("got %s at %s, not an object", jsonTypeOf(node), path)
+
+	case jsonPathIndex:
+		return fmt.Sprintf("got %s at %s, not an array", jsonTypeOf(node), path)
+	}
+
+	return fmt.Sprintf("got %s at %s, not an object or an array", jsonTypeOf(node), path)
+}
+
+func jsonTypeOf(v any) string {
+	switch v.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case float64:
+		return "number"
+	case string:
+		return "string"
+	}
+
+	return fmt.Sprintf("%T", v)
+}
+
+// jsonNodeMatcher converts the expectation of a JSON node. Values that are not matchers are converted to their JSON
+// representation, so that 42 matches the JSON number 42 which is decoded as float64, and a time.Time matches its RFC3339
+// string.
+func jsonNodeMatcher(expected any) Matcher {
+	switch expected.(type) {
+	case Matcher, func() Matcher, regexp.Regexp, *regexp.Regexp:
+		return makeNestedMatcher(expected)
+	}
+
+	b, err := json.Marshal(expected)
+	if err != nil {
+		panic(err)
+	}
+
+	var v any
+
+	if err := json.Unmarshal(b, &v); err != nil {
+		panic(err)
+	}
+
+	return Equal(v)
+}
+
+var (
+	_ Matcher   = (*jsonPathMatcher)(nil)
+	_ Explainer = (*jsonPathMatcher)(nil)
+)
+
+// jsonPathMatcher matches a node of a JSON document.
+type jsonPathMatcher struct {
+	kind  string
+	path  string
+	find  func(doc any) (any, error)
+	value element
+}
+
+// Match determines if the actual is expected.
+func (m jsonPathMatcher) Match(actual any) (bool, error) {
+	doc, ok, err := decodeJSON(actual)
+	if !ok || err != nil {
+		return false, err
+	}
+
+	// A missing path is a mismatch, so that the matcher could be used in Or, Not and AnyElement.
+	node, err := m.find(doc)
+	if err != nil {
+		return false, nil //nolint: nilerr
+	}
+
+	return m.value.matcher.Match(node)
+}
+
+// Expected returns the expectation.
+func (m jsonPathMatcher) Expected() string {
+	return "has " + m.kind + " " + m.path + " with value " + m.value.Expected()
+}
+
+// Explain explains why the actual is not expected.
+func (m jsonPathMatcher) Explain(actual any) *Mismatch {
+	doc, ok, err := decodeJSON(actual)
+	if err != nil {
+		return newMismatch(m, actual, "", err)
+	}
+
+	if !ok {
+		return newMismatch(m, actual, "got invalid json", nil)
+	}
+
+	node, err := m.find(doc)
+	if err != nil {
+		return newMismatch(m, actual, err.Error(), nil)
+	}
+
+	c := Explain(m.value.matcher, node)
+	if c == nil {
+		return nil
+	}
+
+	c.Path = m.path
+
+	result := newMismatch(m, actual, "", c.Err)
+	result.Children = []*Mismatch{c}
+
+	return result
+}
+
+func (m jsonPathMatcher) Format(s fmt.State, _ rune) {
+	_, _ = fmt.Fprintf(s, "<%s>", m.Expected()) //nolint: errcheck
+}
+
+// decodeJSON decodes the actual as a JSON document. The ok flag is false if the actual is not a valid JSON.
+func decodeJSON(actual any) (_ any, ok bool, _ error) {
+	actualBytes, err := jsonVal(actual)
+	if err != nil {
+		return nil, false, err
+	}
+
+	var doc any
+
+	if err := json.Unmarshal(actualBytes, &doc); err != nil {
+		return nil, false, nil
+	}
+
+	return doc, true, nil
+}
+
+// JSONPath matches if the actual is a JSON document that has a node at the path whose value matches the expectation.
+// The actual could be a string, a []byte, or any value that is marshaled to JSON.
+//
+// The supported syntax is the root $, the child .name or ['name'], the index [0] or [-1] from the end, the wildcard .*
+// or [*], and the recursive descent ..name. If the path has a wildcard or a recursive descent, all the found nodes are
+// passed to the matcher as a []any, so it could be combined with Each or Contains. Otherwise, the path must exist.
+//
+// The nodes are decoded by encoding/json, so numbers are float64, objects are map[string]any and arrays are []any. The
+// expectation is converted using Match(). Values that are not matchers are compared with their JSON representation, so
+// JSONPath("$.id", 42) matches {"id": 42}, but the expectations given to the other matchers must be float64, like
+// JSONPath("$.ids", ConsistOf(1.0, 2.0)). The matcher panics if the path is invalid.
+//
+// A missing path is a mismatch, so Not(JSONPath("$.error", Any)) matches a document without an error. Explain tells it
+// apart from a mismatched value, like `path not found: no key "email" at $`.
+func JSONPath(path string, expected any) Matcher {
+	segments, err := parseJSONPath(path)
+	if err != nil {
+		panic(err)
+	}
+
+	return jsonPathMatcher{
+		kind: "json path",
+		path: path,
+		find: func(doc any) (any, error) {
+			return findJSONPath(doc, segments)
+		},
+		value: element{value: expected, matcher: jsonNodeMatcher(expected)},
+	}
+}
+
+// JSONPointer matches if the actual is a JSON document that has a node at the pointer, as defined in RFC 6901, whose
+// value matches the expectation. An empty pointer refers to the whole document.
+//
+// See JSONPath for the supported actual values and expectations, and how a missing node is reported. The matcher
+// panics if the pointer is invalid.
+func JSONPointer(pointer string, expected any) Matcher {
+	tokens, err := parseJSONPointer(pointer)
+	if err != nil {
+		panic(err)
+	}
+
+	return jsonPathMatcher{
+		kind: "json pointer",
+		path: pointer,
+		find: func(doc any) (any, error) {
+			return findJSONPointer(doc, tokens)
+		},
+		value: element{value: expected, matcher: jsonNodeMatcher(expected)},
+	}
+}
@@ -0,0 +1,401 @@
+package matcher_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.nhat.io/matcher/v3"
+)
+
+const jsonPathTestDoc = `{
+	"id": 42,
+	"name": "John",
+	"tags": ["a", "b"],
+	"items": [
+		{"id": 1, "price": 9.5},
+		{"id": 2, "price": 20}
+	],
+	"a/b": {"m~n": true},
+	"meta": null
+}`
+
+var jsonPathTestTime = time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+
+func TestJSONPath_Match(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		scenario string
+		matcher  matcher.Matcher
+		actual   any
+		expected bool
+	}{
+		{
+			scenario: "root",
+			matcher:  matcher.JSONPath("$", matcher.HasKey("id")),
+			actual:   jsonPathTestDoc,
+			expected: true,
+		},
+		{
+			scenario: "child",
+			matcher:  matcher.JSONPath("$.id", 42),
+			actual:   jsonPathTestDoc,
+			expected: true,
+		},
+		{
+			scenario: "child - bytes",
+			matcher:  matcher.JSONPath("$.name", "John"),
+			actual:   []byte(jsonPathTestDoc),
+			expected: true,
+		},
+		{
+			scenario: "child - struct",
+			matcher:  matcher.JSONPath("$.Name", "John"),
+			actual:   struct{ Name string }{Name: "John"},
+			expected: true,
+		},
+		{
+			scenario: "child - mismatched",
+			matcher:  matcher.JSONPath("$.id", 43),
+			actual:   jsonPathTestDoc,
+		},
+		{
+			scenario: "child - matcher",
+			matcher:  matcher.JSONPath("$.id", matcher.GreaterThan(40)),
+			actual:   jsonPathTestDoc,
+			expected: true,
+		},
+		{
+			scenario: "child - null",
+			matcher:  matcher.JSONPath("$.meta", nil),
+			actual:   jsonPathTestDoc,
+			expected: true,
+		},
+		{
+			scenario: "child - time",
+			matcher:  matcher.JSONPath("$.t", jsonPathTestTime),
+			actual:   map[string]any{"t": jsonPathTestTime},
+			expected: true,
+		},
+		{
+			scenario: "child - time - mismatched",
+			matcher:  matcher.JSONPath("$.t", jsonPathTestTime),
+			actual:   map[string]any{"t": jsonPathTestTime.Add(time.Second)},
+		},
+		{
+			scenario: "bracket",
+			matcher:  matcher.JSONPath(`$['a/b']["m~n"]`, true),
+			actual:   jsonPathTestDoc,
+			expected: true,
+		},
+		{
+			scenario: "index",
+			matcher:  matcher.JSONPath("$.items[0].id", 1),
+			actual:   jsonPathTestDoc,
+			expected: true,
+		},
+		{
+			scenario: "index - negative",
+			matcher:  matcher.JSONPath("$.items[-1].price", 20),
+			actual:   jsonPathTestDoc,
+			expected: true,
+		},
+		{
+			scenario: "slice",
+			matcher:  matcher.JSONPath("$.tags", []string{"a", "b"}),
+			actual:   jsonPathTestDoc,
+			expected: true,
+		},
+		{
+			scenario: "wildcard",
+			matcher:  matcher.JSONPath("$.items[*].id", matcher.ConsistOf(1.0, 2.0)),
+			actual:   jsonPathTestDoc,
+			expected: true,
+		},
+		{
+			scenario: "wildcard - dot",
+			matcher:  matcher.JSONPath("$.items.*.price", matcher.Each(matcher.GreaterThan(9))),
+			actual:   jsonPathTestDoc,
+			expected: true,
+		},
+		{
+			scenario: "wildcard - no nodes",
+			matcher:  matcher.JSONPath("$.tags[*].id", matcher.IsEmpty()),
+			actual:   jsonPathTestDoc,
+			expected: true,
+		},
+		{
+			scenario: "recursive descent",
+			matcher:  matcher.JSONPath("$..id", matcher.ElementsMatch(42.0, 1.0, 2.0)),
+			actual:   jsonPathTestDoc,
+			expected: true,
+		},
+		{
+			scenario: "recursive descent - bracket",
+			matcher:  matcher.JSONPath("$..[0]", matcher.Contains("a")),
+			actual:   jsonPathTestDoc,
+			expected: true,
+		},
+		{
+			scenario: "invalid json",
+			matcher:  matcher.JSONPath("$", matcher.Any),
+			actual:   "{",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.scenario, func(t *testing.T) {
+			t.Parallel()
+
+			result, err := tc.matcher.Match(tc.actual)
+
+			assert.Equal(t, tc.expected, result)
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestJSONPointer_Match(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		scenario string
+		matcher  matcher.Matcher
+		expected bool
+	}{
+		{
+			scenario: "root",
+			matcher:  matcher.JSONPointer("", matcher.HasKey("id")),
+			expected: true,
+		},
+		{
+			scenario: "child",
+			matcher:  matcher.JSONPointer("/name", "John"),
+			expected: true,
+		},
+		{
+			scenario: "child - mismatched",
+			matcher:  matcher.JSONPointer("/name", "Jane"),
+		},
+		{
+			scenario: "index",
+			matcher:  matcher.JSONPointer("/items/1/id", 2),
+			expected: true,
+		},
+		{
+			scenario: "escaped",
+			matcher:  matcher.JSONPointer("/a~1b/m~0n", true),
+			expected: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.scenario, func(t *testing.T) {
+			t.Parallel()
+
+			result, err := tc.matcher.Match(jsonPathTestDoc)
+
+			assert.Equal(t, tc.expected, result)
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestJSONPath_Match_Error(t *testing.T) {
+	t.Parallel()
+
+	result, err := matcher.JSONPath("$.id", matcher.Any).Match(make(chan int))
+
+	assert.False(t, result)
+	require.EqualError(t, err, `json: unsupported type: chan int`)
+
+	result, err = matcher.JSONPointer("/id", matcher.Len(1)).Match(jsonPathTestDoc)
+
+	assert.False(t, result)
+	require.EqualError(t, err, `len: value of type float64 does not have a length`)
+}
+
+func TestJSONPath_Match_NotFound(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		scenario string
+		matcher  matcher.Matcher
+		expected bool
+	}{
+		{
+			scenario: "path",
+			matcher:  matcher.JSONPath("$.email", matcher.Any),
+		},
+		{
+			scenario: "pointer",
+			matcher:  matcher.JSONPointer("/items/2", matcher.Any),
+		},
+		{
+			scenario: "not",
+			matcher:  matcher.Not(matcher.JSONPath("$.error", matcher.Any)),
+			expected: true,
+		},
+		{
+			scenario: "or",
+			matcher:  matcher.Or(matcher.JSONPath("$.email", matcher.Any), matcher.JSONPath("$.id", 42)),
+			expected: true,
+		},
+		{
+			scenario: "each",
+			matcher:  matcher.JSONPath("$.items", matcher.Each(matcher.Not(matcher.JSONPath("$.discount", matcher.Any)))),
+			expected: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.scenario, func(t *testing.T) {
+			t.Parallel()
+
+			result, err := tc.matcher.Match(jsonPathTestDoc)
+
+			assert.Equal(t, tc.expected, result)
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestJSONPath_InvalidPath(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		scenario string
+		create   func()
+		expected string
+	}{
+		{
+			scenario: "no root",
+			create:   func() { matcher.JSONPath("id", matcher.Any) },
+			expected: `invalid json path "id": must start with $`,
+		},
+		{
+			scenario: "missing name",
+			create:   func() { matcher.JSONPath("$.", matcher.Any) },
+			expected: `invalid json path "$.": missing name at offset 2`,
+		},
+		{
+			scenario: "missing bracket",
+			create:   func() { matcher.JSONPath("$[0", matcher.Any) },
+			expected: `invalid json path "$[0": missing ] at offset 1`,
+		},
+		{
+			scenario: "invalid index",
+			create:   func() { matcher.JSONPath("$[a]", matcher.Any) },
+			expected: `invalid json path "$[a]": invalid index at offset 2`,
+		},
+		{
+			scenario: "unterminated string",
+			create:   func() { matcher.JSONPath("$['a]", matcher.Any) },
+			expected: `invalid json path "$['a]": unterminated string at offset 2`,
+		},
+		{
+			scenario: "unexpected",
+			create:   func() { matcher.JSONPath("$a", matcher.Any) },
+			expected: `invalid json path "$a": unexpected 'a' at offset 1`,
+		},
+		{
+			scenario: "pointer",
+			create:   func() { matcher.JSONPointer("id", matcher.Any) },
+			expected: `invalid json pointer "id": must be empty or start with /`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.scenario, func(t *testing.T) {
+			t.Parallel()
+
+			assert.PanicsWithError(t, tc.expected, tc.create)
+		})
+	}
+}
+
+func TestJSONPath_Expected(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, `has json path $.name with value "John"`, matcher.JSONPath("$.name", "John").Expected())
+	assert.Equal(t, `has json pointer /id with value > 40`, matcher.JSONPointer("/id", matcher.GreaterThan(40)).Expected())
+	assert.Equal(t, `<has json path $.id with value 42>`, fmt.Sprintf("%#v", matcher.JSONPath("$.id", 42)))
+}
+
+func TestJSONPath_Explain(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		scenario string
+		matcher  matcher.Matcher
+		actual   any
+		expected string
+	}{
+		{
+			scenario: "mismatched",
+			matcher:  matcher.JSONPath("$.items[1].price", matcher.LessThan(10)),
+			actual:   `{"items": [{}, {"price": 20}]}`,
+			expected: `expected has json path $.items[1].price with value < 10, got string("{\"items\": [{}, {\"price\": 20}]}")
+  $.items[1].price: expected < 10, got float64(20)`,
+		},
+		{
+			scenario: "no key",
+			matcher:  matcher.JSONPath("$.items[0].id", 1),
+			actual:   `{"items": [{}]}`,
+			expected: `expected has json path $.items[0].id with value 1, path not found: no key "id" at $.items[0]`,
+		},
+		{
+			scenario: "out of range",
+			matcher:  matcher.JSONPath("$['items'][3]", matcher.Any),
+			actual:   `{"items": [{}]}`,
+			expected: `expected has json path $['items'][3] with value is anything, path not found: index 3 out of range at $.items, len 1`,
+		},
+		{
+			scenario: "not an array",
+			matcher:  matcher.JSONPath("$.items[0]", matcher.Any),
+			actual:   `{"items": "a"}`,
+			expected: `expected has json path $.items[0] with value is anything, path not found: got string at $.items, not an array`,
+		},
+		{
+			scenario: "not an object",
+			matcher:  matcher.JSONPath("$.items.id", matcher.Any),
+			actual:   `{"items": []}`,
+			expected: `expected has json path $.items.id with value is anything, path not found: got array at $.items, not an object`,
+		},
+		{
+			scenario: "pointer - invalid index",
+			matcher:  matcher.JSONPointer("/items/a~1b", matcher.Any),
+			actual:   `{"items": []}`,
+			expected: `expected has json pointer /items/a~1b with value is anything, path not found: invalid index "a/b" at "/items"`,
+		},
+		{
+			scenario: "pointer - not a container",
+			matcher:  matcher.JSONPointer("/id/0", matcher.Any),
+			actual:   `{"id": 1}`,
+			expected: `expected has json pointer /id/0 with value is anything, path not found: got number at "/id", not an object or an array`,
+		},
+		{
+			scenario: "invalid json",
+			matcher:  matcher.JSONPointer("/id", matcher.Any),
+			actual:   `{`,
+			expected: `expected has json pointer /id with value is anything, got invalid json`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.scenario, func(t *testing.T) {
+			t.Parallel()
+
+			actual := matcher.Explain(tc.matcher, tc.actual)
+
+			require.NotNil(t, actual)
+			assert.Equal(t, tc.expected, actual.String())
+		})
+	}
+
+	assert.Nil(t, matcher.Explain(matcher.JSONPath("$.id", 42), jsonPathTestDoc))
+}
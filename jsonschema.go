@@ -0,0 +1,193 @@
+package matcher
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/santhosh-tekuri/jsonschema/v6"
+)
+
+// jsonSchemaInlineURL is the location of the inline schemas, the relative references are resolved against it.
+const jsonSchemaInlineURL = "inline-schema.json"
+
+var (
+	_ Matcher   = (*jsonSchemaMatcher)(nil)
+	_ Explainer = (*jsonSchemaMatcher)(nil)
+)
+
+// jsonSchemaMatcher validates the actual against a JSON Schema.
+type jsonSchemaMatcher struct {
+	schema   *jsonschema.Schema
+	expected string
+	source   json.RawMessage
+}
+
+// Match determines if the actual is expected.
+func (m jsonSchemaMatcher) Match(actual any) (bool, error) {
+	doc, ok, err := jsonSchemaInstance(actual)
+	if !ok || err != nil {
+		return false, err
+	}
+
+	violations, err := m.validate(doc)
+	if err != nil {
+		return false, err
+	}
+
+	return len(violations) == 0, nil
+}
+
+// Expected returns the expectation.
+func (m jsonSchemaMatcher) Expected() string {
+	return m.expected
+}
+
+// Explain explains why the actual is not expected.
+func (m jsonSchemaMatcher) Explain(actual any) *Mismatch {
+	doc, ok, err := jsonSchemaInstance(actual)
+	if err != nil {
+		return newMismatch(m, actual, "", err)
+	}
+
+	if !ok {
+		return newMismatch(m, actual, "got invalid json", nil)
+	}
+
+	violations, err := m.validate(doc)
+	if err != nil {
+		return newMismatch(m, actual, "", err)
+	}
+
+	if len(violations) == 0 {
+		return nil
+	}
+
+	return newMismatch(m, actual, "got json schema violations: "+strings.Join(violations, "; "), nil)
+}
+
+func (m jsonSchemaMatcher) Format(s fmt.State, _ rune) {
+	_, _ = fmt.Fprintf(s, "<%s>", m.expected) //nolint: errcheck
+}
+
+// validate validates the document, and lists the violations, one for each failing keyword. The error is not a
+// violation, but a failure of the validation.
+func (m jsonSchemaMatcher) validate(doc any) ([]string, error) {
+	err := m.schema.Validate(doc)
+	if err == nil {
+		return nil, nil
+	}
+
+	var verr *jsonschema.ValidationError
+
+	if !errors.As(err, &verr) {
+		return nil, err
+	}
+
+	var violations []string
+
+	for _, u := range verr.BasicOutput().Errors {
+		if u.Error == nil {
+			continue
+		}
+
+		violations = append(violations, fmt.Sprintf("at %q: %s", u.InstanceLocation, u.Error.String()))
+	}
+
+	// The violations are not reported in a stable order.
+	sort.Strings(violations)
+
+	return violations, nil
+}
+
+// jsonSchemaInstance decodes the actual for the validation. The ok flag is false if the actual is not a valid JSON.
+func jsonSchemaInstance(actual any) (_ any, ok bool, _ error) {
+	actualBytes, err := jsonVal(actual)
+	if err != nil {
+		return nil, false, err
+	}
+
+	doc, err := jsonschema.UnmarshalJSON(bytes.NewReader(actualBytes))
+	if err != nil {
+		return nil, false, nil //nolint: nilerr
+	}
+
+	return doc, true, nil
+}
+
+// isInlineJSONSchema determines if the string is a schema, instead of the path to a schema.
+func isInlineJSONSchema(s string) bool {
+	s = strings.TrimSpace(s)
+
+	return strings.HasPrefix(s, "{") || s == "true" || s == "false"
+}
+
+// JSONSchema matches if the actual is valid against a JSON Schema. The actual could be a string, a []byte, a
+// json.RawMessage, or any value that is marshaled to JSON.
+//
+// The schema could be a string or a []byte of JSON, any value that is marshaled to JSON, or the path to a schema file.
+// A string is a path unless it starts with "{", or is "true" or "false", which are the boolean schemas. The schemas
+// without $schema are Draft 2020-12. The matcher panics if the schema is invalid.
+//
+// If the actual is not valid, it is a mismatch, not an error, so the matcher works in Or and Not. Explain lists the
+// violations, one for each failing keyword, like `got json schema violations: at "/id": got string, want integer`.
+// Match only returns an error if the actual could not be marshaled to JSON.
+func JSONSchema(schema any) Matcher {
+	m, err := newJSONSchemaMatcher(schema)
+	if err != nil {
+		panic(err)
+	}
+
+	return m
+}
+
+func newJSONSchemaMatcher(schema any) (Matcher, error) {
+	c := jsonschema.NewCompiler()
+	c.DefaultDraft(jsonschema.Draft2020)
+
+	loc := jsonSchemaInlineURL
+
+	var (
+		expected string
+		source   json.RawMessage
+	)
+
+	if s, ok := schema.(string); ok && !isInlineJSONSchema(s) {
+		loc = s
+		expected = "matches json schema " + s
+		source, _ = json.Marshal(s) //nolint: errcheck
+	} else {
+		b, err := jsonVal(schema)
+		if err != nil {
+			return nil, err
+		}
+
+		doc, err := jsonschema.UnmarshalJSON(bytes.NewReader(b))
+		if err != nil {
+			return nil, fmt.Errorf("invalid json schema: %w", err)
+		}
+
+		if err := c.AddResource(loc, doc); err != nil {
+			return nil, err
+		}
+
+		var buf bytes.Buffer
+
+		if err := json.Compact(&buf, b); err != nil {
+			return nil, err
+		}
+
+		expected = "matches json schema " + buf.String()
+		source = buf.Bytes()
+	}
+
+	sch, err := c.Compile(loc)
+	if err != nil {
+		return nil, err
+	}
+
+	return jsonSchemaMatcher{schema: sch, expected: expected, source: source}, nil
+}
@@ -0,0 +1,198 @@
+package matcher_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.nhat.io/matcher/v3"
+)
+
+const jsonSchemaTestSchema = `{
+	"type": "object",
+	"properties": {
+		"id": {"type": "integer", "minimum": 1},
+		"name": {"type": "string"}
+	},
+	"required": ["id"]
+}`
+
+func TestJSONSchema_Match(t *testing.T) {
+	t.Parallel()
+
+	type user struct {
+		ID   int    `json:"id"`
+		Name string `json:"name"`
+	}
+
+	testCases := []struct {
+		scenario       string
+		schema         any
+		actual         any
+		expected       bool
+		expectedReason string
+	}{
+		{
+			scenario: "inline string",
+			schema:   jsonSchemaTestSchema,
+			actual:   `{"id": 1, "name": "John"}`,
+			expected: true,
+		},
+		{
+			scenario:       "inline string - invalid",
+			schema:         jsonSchemaTestSchema,
+			actual:         `{"id": 0}`,
+			expectedReason: `got json schema violations: at "/id": minimum: got 0, want 1`,
+		},
+		{
+			scenario: "inline bytes",
+			schema:   []byte(jsonSchemaTestSchema),
+			actual:   []byte(`{"id": 1}`),
+			expected: true,
+		},
+		{
+			scenario:       "inline raw message",
+			schema:         json.RawMessage(jsonSchemaTestSchema),
+			actual:         json.RawMessage(`{"name": "John"}`),
+			expectedReason: `got json schema violations: at "": missing property 'id'`,
+		},
+		{
+			scenario: "inline map",
+			schema:   map[string]any{"type": "string", "minLength": 3},
+			actual:   "\"foo\"",
+			expected: true,
+		},
+		{
+			scenario:       "boolean schema",
+			schema:         "false",
+			actual:         `{}`,
+			expectedReason: `got json schema violations: at "": false schema`,
+		},
+		{
+			scenario: "struct actual",
+			schema:   jsonSchemaTestSchema,
+			actual:   user{ID: 42, Name: "John"},
+			expected: true,
+		},
+		{
+			scenario:       "struct actual - invalid",
+			schema:         jsonSchemaTestSchema,
+			actual:         user{Name: "John"},
+			expectedReason: `got json schema violations: at "/id": minimum: got 0, want 1`,
+		},
+		{
+			scenario: "file",
+			schema:   "testdata/user.schema.json",
+			actual:   `{"id": 1, "name": "John", "tags": ["a"]}`,
+			expected: true,
+		},
+		{
+			scenario:       "file - invalid",
+			schema:         "testdata/user.schema.json",
+			actual:         `{"id": 1, "name": "John", "tags": [1]}`,
+			expectedReason: `got json schema violations: at "/tags/0": got number, want string`,
+		},
+		{
+			scenario:       "draft 2020-12",
+			schema:         `{"prefixItems": [{"type": "string"}], "items": false}`,
+			actual:         `["a", "b"]`,
+			expectedReason: `got json schema violations: at "/0": false schema`,
+		},
+		{
+			scenario: "invalid json",
+			schema:   jsonSchemaTestSchema,
+			actual:   `{`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.scenario, func(t *testing.T) {
+			t.Parallel()
+
+			m := matcher.JSONSchema(tc.schema)
+
+			result, err := m.Match(tc.actual)
+
+			assert.Equal(t, tc.expected, result)
+			require.NoError(t, err)
+
+			if tc.expectedReason != "" {
+				actual := matcher.Explain(m, tc.actual)
+
+				require.NotNil(t, actual)
+				assert.Equal(t, tc.expectedReason, actual.Reason)
+			}
+		})
+	}
+}
+
+func TestJSONSchema_Match_Error(t *testing.T) {
+	t.Parallel()
+
+	result, err := matcher.JSONSchema(jsonSchemaTestSchema).Match(make(chan int))
+
+	assert.False(t, result)
+	require.EqualError(t, err, `json: unsupported type: chan int`)
+}
+
+func TestJSONSchema_Match_Logical(t *testing.T) {
+	t.Parallel()
+
+	m := matcher.Or(matcher.JSONSchema(`{"type": "string"}`), matcher.JSONSchema(`{"type": "integer"}`))
+
+	result, err := m.Match(`1`)
+
+	assert.True(t, result)
+	require.NoError(t, err)
+
+	result, err = matcher.Not(matcher.JSONSchema(`{"type": "string"}`)).Match(`1`)
+
+	assert.True(t, result)
+	require.NoError(t, err)
+}
+
+func TestJSONSchema_InvalidSchema(t *testing.T) {
+	t.Parallel()
+
+	assert.Panics(t, func() {
+		matcher.JSONSchema(`{"type": 42}`)
+	})
+
+	assert.Panics(t, func() {
+		matcher.JSONSchema(`{`)
+	})
+
+	assert.Panics(t, func() {
+		matcher.JSONSchema("testdata/unknown.schema.json")
+	})
+}
+
+func TestJSONSchema_Expected(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, `matches json schema testdata/user.schema.json`, matcher.JSONSchema("testdata/user.schema.json").Expected())
+	assert.Equal(t, `matches json schema {"type":"string"}`, matcher.JSONSchema(`{ "type": "string" }`).Expected())
+	assert.Equal(t, `<matches json schema {"type":"string"}>`, fmt.Sprintf("%#v", matcher.JSONSchema(map[string]any{"type": "string"})))
+}
+
+func TestJSONSchema_Explain(t *testing.T) {
+	t.Parallel()
+
+	m := matcher.JSONSchema("testdata/user.schema.json")
+
+	actual := matcher.Explain(m, `{"id": 0, "tags": [1]}`)
+	expected := `expected matches json schema testdata/user.schema.json, got json schema violations: at "": missing property 'name'; at "/id": minimum: got 0, want 1; at "/tags/0": got number, want string`
+
+	require.NotNil(t, actual)
+	assert.Equal(t, expected, actual.String())
+
+	actual = matcher.Explain(m, `{`)
+
+	require.NotNil(t, actual)
+	assert.Equal(t, `expected matches json schema testdata/user.schema.json, got invalid json`, actual.String())
+
+	assert.Nil(t, matcher.Explain(m, `{"id": 1, "name": "John"}`))
+}
@@ -0,0 +1,329 @@
+package matcher
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// mapEntry is an entry of a map.
+type mapEntry struct {
+	key   any
+	value any
+}
+
+// mapVal gets the entries of a map or a pointer to a map, sorted by the string representations of the keys.
+func mapVal(v any) ([]mapEntry, bool) {
+	val := indirectVal(reflect.ValueOf(v))
+	if val.Kind() != reflect.Map {
+		return nil, false
+	}
+
+	keys := sortedMapKeys(val)
+	entries := make([]mapEntry, len(keys))
+
+	for i, k := range keys {
+		entries[i] = mapEntry{key: k.Interface(), value: val.MapIndex(k).Interface()}
+	}
+
+	return entries, true
+}
+
+// findEntries finds the entries whose keys match.
+func findEntries(key Matcher, entries []mapEntry) ([]mapEntry, error) {
+	var found []mapEntry
+
+	for _, e := range entries {
+		ok, err := key.Match(e.key)
+		if err != nil {
+			return nil, err
+		}
+
+		if ok {
+			found = append(found, e)
+		}
+	}
+
+	return found, nil
+}
+
+var (
+	_ Matcher   = (*entryMatcher)(nil)
+	_ Explainer = (*entryMatcher)(nil)
+)
+
+// entryMatcher checks whether a map has an entry whose key and value match.
+type entryMatcher struct {
+	key      element
+	value    element
+	expected string
+	operator string
+}
+
+// Match determines if the actual is expected.
+func (m entryMatcher) Match(actual any) (bool, error) {
+	entries, ok := mapVal(actual)
+	if !ok {
+		return false, nil
+	}
+
+	found, err := findEntries(m.key.matcher, entries)
+	if err != nil {
+		return false, err
+	}
+
+	for _, e := range found {
+		if ok, err := m.value.matcher.Match(e.value); err != nil {
+			return false, err
+		} else if ok {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// Expected returns the expectation.
+func (m entryMatcher) Expected() string {
+	return m.expected
+}
+
+// Explain explains why the actual is not expected.
+func (m entryMatcher) Explain(actual any) *Mismatch {
+	if _, ok := mapVal(actual); !ok {
+		return newMismatch(m, actual, fmt.Sprintf("got %T, not a map", actual), nil)
+	}
+
+	return explain(m, actual, "")
+}
+
+func (m entryMatcher) Format(s fmt.State, _ rune) {
+	_, _ = fmt.Fprintf(s, "<%s>", m.expected) //nolint: errcheck
+}
+
+var (
+	_ Matcher   = (*keysMatcher)(nil)
+	_ Explainer = (*keysMatcher)(nil)
+)
+
+// keysMatcher matches the keys of a map.
+type keysMatcher struct {
+	matcher Matcher
+}
+
+// Match determines if the actual is expected.
+func (m keysMatcher) Match(actual any) (bool, error) {
+	entries, ok := mapVal(actual)
+	if !ok {
+		return false, nil
+	}
+
+	return m.matcher.Match(mapKeys(entries))
+}
+
+// Expected returns the expectation.
+func (m keysMatcher) Expected() string {
+	return "keys " + m.matcher.Expected()
+}
+
+// Explain explains why the actual is not expected.
+func (m keysMatcher) Explain(actual any) *Mismatch {
+	entries, ok := mapVal(actual)
+	if !ok {
+		return newMismatch(m, actual, fmt.Sprintf("got %T, not a map", actual), nil)
+	}
+
+	c := Explain(m.matcher, mapKeys(entries))
+	if c == nil {
+		return nil
+	}
+
+	result := newMismatch(m, actual, "", c.Err)
+	result.Children = []*Mismatch{c}
+
+	return result
+}
+
+func (m keysMatcher) Format(s fmt.State, _ rune) {
+	_, _ = fmt.Fprintf(s, "<%s>", m.Expected()) //nolint: errcheck
+}
+
+func mapKeys(entries []mapEntry) []any {
+	keys := make([]any, len(entries))
+
+	for i, e := range entries {
+		keys[i] = e.key
+	}
+
+	return keys
+}
+
+var (
+	_ Matcher   = (*mapContainingMatcher)(nil)
+	_ Explainer = (*mapContainingMatcher)(nil)
+)
+
+// mapContainingMatcher checks whether a map contains all the expected entries.
+type mapContainingMatcher struct {
+	keys   []element
+	values []Matcher
+}
+
+// Match determines if the actual is expected.
+func (m mapContainingMatcher) Match(actual any) (bool, error) {
+	entries, ok := mapVal(actual)
+	if !ok {
+		return false, nil
+	}
+
+	for i, key := range m.keys {
+		found, err := findEntries(key.matcher, entries)
+		if err != nil || len(found) == 0 {
+			return false, err
+		}
+
+		for _, e := range found {
+			if ok, err := m.values[i].Match(e.value); err != nil || !ok {
+				return false, err
+			}
+		}
+	}
+
+	return true, nil
+}
+
+// Expected returns the expectation.
+func (m mapContainingMatcher) Expected() string {
+	entries := make([]string, len(m.keys))
+
+	for i, k := range m.keys {
+		entries[i] = k.Expected() + ": " + m.values[i].Expected()
+	}
+
+	return "contains entries {" + strings.Join(entries, ", ") + "}"
+}
+
+// Explain explains why the actual is not expected.
+func (m mapContainingMatcher) Explain(actual any) *Mismatch {
+	entries, ok := mapVal(actual)
+	if !ok {
+		return newMismatch(m, actual, fmt.Sprintf("got %T, not a map", actual), nil)
+	}
+
+	var children []*Mismatch
+
+	for i, key := range m.keys {
+		found, err := findEntries(key.matcher, entries)
+		if err != nil {
+			return newMismatch(m, actual, "", err)
+		}
+
+		if len(found) == 0 {
+			children = append(children, &Mismatch{Path: "[" + key.matcher.Expected() + "]", Expected: m.values[i].Expected(), Reason: "key not found"})
+
+			continue
+		}
+
+		for _, e := range found {
+			if c := Explain(m.values[i], e.value); c != nil {
+				c.Path = fmt.Sprintf("[%v]", e.key)
+				children = append(children, c)
+			}
+		}
+	}
+
+	if len(children) == 0 {
+		return nil
+	}
+
+	result := newMismatch(m, actual, "", nil)
+	result.Children = children
+
+	return result
+}
+
+func (m mapContainingMatcher) Format(s fmt.State, _ rune) {
+	_, _ = fmt.Fprintf(s, "<%s>", m.Expected()) //nolint: errcheck
+}
+
+// HasKey matches if the actual is a map, or a pointer to a map, that has a key matching the expectation. The
+// expectation is converted using Match().
+func HasKey(key any) Matcher {
+	k := element{value: key, matcher: makeNestedMatcher(key)}
+
+	return entryMatcher{
+		key:      k,
+		value:    element{matcher: Any},
+		expected: "has key " + k.Expected(),
+		operator: operatorHasKey,
+	}
+}
+
+// HasValue matches if the actual is a map, or a pointer to a map, that has a value matching the expectation. The
+// expectation is converted using Match().
+func HasValue(value any) Matcher {
+	v := element{value: value, matcher: makeNestedMatcher(value)}
+
+	return entryMatcher{
+		key:      element{matcher: Any},
+		value:    v,
+		expected: "has value " + v.Expected(),
+		operator: operatorHasValue,
+	}
+}
+
+// HasEntry matches if the actual is a map, or a pointer to a map, that has an entry whose key and value match the
+// expectations. The expectations are converted using Match().
+func HasEntry(key, value any) Matcher {
+	k := element{value: key, matcher: makeNestedMatcher(key)}
+	v := element{value: value, matcher: makeNestedMatcher(value)}
+
+	return entryMatcher{
+		key:      k,
+		value:    v,
+		expected: "has entry " + k.Expected() + " with value " + v.Expected(),
+		operator: operatorHasEntry,
+	}
+}
+
+// KeysMatch matches if the keys of the actual, which is a map or a pointer to a map, match the expectation. The keys are
+// passed to the matcher as a []any, sorted by their string representations. The expectation is converted using
+// Match().
+func KeysMatch(expected any) Matcher {
+	return keysMatcher{matcher: makeNestedMatcher(expected)}
+}
+
+// MapContaining matches if the actual is a map, or a pointer to a map, that contains all the expected entries. Extra
+// entries in the actual are ignored.
+//
+// The keys and the values are converted using Match(), and every entry whose key matches must have a matching value. A
+// value of type map[any]any is matched using MapContaining, so nested maps are partially matched as well.
+func MapContaining(expected map[any]any) Matcher {
+	keys := make([]any, 0, len(expected))
+
+	for k := range expected {
+		keys = append(keys, k)
+	}
+
+	sort.Slice(keys, func(i, j int) bool {
+		return fmt.Sprint(keys[i]) < fmt.Sprint(keys[j])
+	})
+
+	m := mapContainingMatcher{
+		keys:   make([]element, len(keys)),
+		values: make([]Matcher, len(keys)),
+	}
+
+	for i, k := range keys {
+		m.keys[i] = element{value: k, matcher: makeNestedMatcher(k)}
+
+		if v, ok := expected[k].(map[any]any); ok {
+			m.values[i] = MapContaining(v)
+		} else {
+			m.values[i] = makeNestedMatcher(expected[k])
+		}
+	}
+
+	return m
+}
@@ -0,0 +1,363 @@
+package matcher_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.nhat.io/matcher/v3"
+)
+
+func TestMap_Match(t *testing.T) {
+	t.Parallel()
+
+	payload := map[string]any{
+		"id":   42,
+		"name": "foobar",
+		"tags": []any{"foo", "bar"},
+		"meta": map[string]any{
+			"version": 1,
+			"owner":   "john",
+		},
+	}
+
+	testCases := []struct {
+		scenario string
+		matcher  matcher.Matcher
+		actual   any
+		expected bool
+	}{
+		{
+			scenario: "has key",
+			matcher:  matcher.HasKey("id"),
+			actual:   payload,
+			expected: true,
+		},
+		{
+			scenario: "has key - pointer",
+			matcher:  matcher.HasKey("id"),
+			actual:   &payload,
+			expected: true,
+		},
+		{
+			scenario: "has key - matcher",
+			matcher:  matcher.HasKey(matcher.HasPrefix("na")),
+			actual:   payload,
+			expected: true,
+		},
+		{
+			scenario: "has key - missing",
+			matcher:  matcher.HasKey("email"),
+			actual:   payload,
+		},
+		{
+			scenario: "has key - int",
+			matcher:  matcher.HasKey(1),
+			actual:   map[int]string{1: "foo"},
+			expected: true,
+		},
+		{
+			scenario: "has key - not a map",
+			matcher:  matcher.HasKey("id"),
+			actual:   []string{"id"},
+		},
+		{
+			scenario: "has key - nil",
+			matcher:  matcher.HasKey("id"),
+			actual:   nil,
+		},
+		{
+			scenario: "has value",
+			matcher:  matcher.HasValue("foobar"),
+			actual:   payload,
+			expected: true,
+		},
+		{
+			scenario: "has value - matcher",
+			matcher:  matcher.HasValue(matcher.GreaterThan(40)),
+			actual:   payload,
+			expected: true,
+		},
+		{
+			scenario: "has value - missing",
+			matcher:  matcher.HasValue("john"),
+			actual:   payload,
+		},
+		{
+			scenario: "has entry",
+			matcher:  matcher.HasEntry("id", 42),
+			actual:   payload,
+			expected: true,
+		},
+		{
+			scenario: "has entry - matcher",
+			matcher:  matcher.HasEntry("tags", matcher.Contains("bar")),
+			actual:   payload,
+			expected: true,
+		},
+		{
+			scenario: "has entry - value mismatched",
+			matcher:  matcher.HasEntry("id", 43),
+			actual:   payload,
+		},
+		{
+			scenario: "has entry - key missing",
+			matcher:  matcher.HasEntry("email", "john"),
+			actual:   payload,
+		},
+		{
+			scenario: "keys match",
+			matcher:  matcher.KeysMatch(matcher.ElementsMatch("id", "name", "tags", "meta")),
+			actual:   payload,
+			expected: true,
+		},
+		{
+			scenario: "keys match - sorted",
+			matcher:  matcher.KeysMatch(matcher.ConsistOf("id", "meta", "name", "tags")),
+			actual:   payload,
+			expected: true,
+		},
+		{
+			scenario: "keys match - mismatched",
+			matcher:  matcher.KeysMatch(matcher.Len(3)),
+			actual:   payload,
+		},
+		{
+			scenario: "keys match - not a map",
+			matcher:  matcher.KeysMatch(matcher.Len(3)),
+			actual:   42,
+		},
+		{
+			scenario: "map containing",
+			matcher: matcher.MapContaining(map[any]any{
+				"id":   matcher.GreaterThan(40),
+				"name": "foobar",
+			}),
+			actual:   payload,
+			expected: true,
+		},
+		{
+			scenario: "map containing - nested",
+			matcher: matcher.MapContaining(map[any]any{
+				"meta": map[any]any{"owner": matcher.IsNotEmpty()},
+			}),
+			actual:   payload,
+			expected: true,
+		},
+		{
+			scenario: "map containing - nested mismatched",
+			matcher: matcher.MapContaining(map[any]any{
+				"meta": map[any]any{"owner": "jane"},
+			}),
+			actual: payload,
+		},
+		{
+			scenario: "map containing - key missing",
+			matcher:  matcher.MapContaining(map[any]any{"email": matcher.Any}),
+			actual:   payload,
+		},
+		{
+			scenario: "map containing - key matcher",
+			matcher:  matcher.MapContaining(map[any]any{matcher.Regex("^(id|name)$"): matcher.IsNotEmpty()}),
+			actual:   payload,
+			expected: true,
+		},
+		{
+			scenario: "map containing - empty",
+			matcher:  matcher.MapContaining(map[any]any{}),
+			actual:   map[string]int{},
+			expected: true,
+		},
+		{
+			scenario: "map containing - not a map",
+			matcher:  matcher.MapContaining(map[any]any{}),
+			actual:   "foobar",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.scenario, func(t *testing.T) {
+			t.Parallel()
+
+			result, err := tc.matcher.Match(tc.actual)
+
+			assert.Equal(t, tc.expected, result)
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestMap_Match_Error(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		scenario string
+		matcher  matcher.Matcher
+	}{
+		{
+			scenario: "has key",
+			matcher:  matcher.HasKey(matcher.Len(1)),
+		},
+		{
+			scenario: "has value",
+			matcher:  matcher.HasValue(matcher.Len(1)),
+		},
+		{
+			scenario: "keys match",
+			matcher:  matcher.KeysMatch(matcher.Each(matcher.Len(1))),
+		},
+		{
+			scenario: "map containing",
+			matcher:  matcher.MapContaining(map[any]any{1: matcher.Len(1)}),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.scenario, func(t *testing.T) {
+			t.Parallel()
+
+			result, err := tc.matcher.Match(map[int]int{1: 1})
+
+			assert.False(t, result)
+			require.EqualError(t, err, `len: value of type int does not have a length`)
+		})
+	}
+}
+
+func TestMap_Expected(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		scenario string
+		matcher  matcher.Matcher
+		expected string
+	}{
+		{
+			scenario: "has key",
+			matcher:  matcher.HasKey("id"),
+			expected: `has key "id"`,
+		},
+		{
+			scenario: "has value",
+			matcher:  matcher.HasValue(42),
+			expected: `has value 42`,
+		},
+		{
+			scenario: "has entry",
+			matcher:  matcher.HasEntry("id", matcher.Or(1, 2)),
+			expected: `has entry "id" with value (1 or 2)`,
+		},
+		{
+			scenario: "keys match",
+			matcher:  matcher.KeysMatch(matcher.Contains("id")),
+			expected: `keys contains "id"`,
+		},
+		{
+			scenario: "map containing",
+			matcher:  matcher.MapContaining(map[any]any{"name": "foo", "id": matcher.GreaterThan(1), "meta": map[any]any{"version": 1}}),
+			expected: `contains entries {"id": > 1, "meta": contains entries {"version": 1}, "name": foo}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.scenario, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tc.expected, tc.matcher.Expected())
+		})
+	}
+}
+
+func TestMapMatcher_Format(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, `<has key "id">`, fmt.Sprintf("%#v", matcher.HasKey("id")))
+	assert.Equal(t, `<keys len is 1>`, fmt.Sprintf("%#v", matcher.KeysMatch(matcher.Len(1))))
+	assert.Equal(t, `<contains entries {"id": 1}>`, fmt.Sprintf("%#v", matcher.MapContaining(map[any]any{"id": 1})))
+}
+
+func TestMap_Explain(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		scenario string
+		matcher  matcher.Matcher
+		actual   any
+		expected string
+	}{
+		{
+			scenario: "has key",
+			matcher:  matcher.HasKey("id"),
+			actual:   map[string]int{"name": 1},
+			expected: `expected has key "id", got map[string]int{"name":1}`,
+		},
+		{
+			scenario: "has key not a map",
+			matcher:  matcher.HasKey("id"),
+			actual:   42,
+			expected: `expected has key "id", got int, not a map`,
+		},
+		{
+			scenario: "keys match",
+			matcher:  matcher.KeysMatch(matcher.Len(2)),
+			actual:   map[string]int{"name": 1},
+			expected: `expected keys len is 2, got map[string]int{"name":1}
+  expected len is 2, got len 1`,
+		},
+		{
+			scenario: "keys match not a map",
+			matcher:  matcher.KeysMatch(matcher.Len(2)),
+			actual:   nil,
+			expected: `expected keys len is 2, got <nil>, not a map`,
+		},
+		{
+			scenario: "map containing",
+			matcher: matcher.MapContaining(map[any]any{
+				"id":    matcher.GreaterThan(1),
+				"email": matcher.Any,
+				"meta":  map[any]any{"version": 2},
+			}),
+			actual: map[string]any{"id": 1, "meta": map[string]any{"version": 1}},
+			expected: `expected contains entries {"email": is anything, "id": > 1, "meta": contains entries {"version": 2}}, got map[string]interface {}{"id":1, "meta":map[string]interface {}{"version":1}}
+  [email]: expected is anything, key not found
+  [id]: expected > 1, got int(1)
+  [meta]: expected contains entries {"version": 2}, got map[string]interface {}{"version":1}
+    [version]: expected 2, got int(1)`,
+		},
+		{
+			scenario: "map containing not a map",
+			matcher:  matcher.MapContaining(map[any]any{}),
+			actual:   42,
+			expected: `expected contains entries {}, got int, not a map`,
+		},
+		{
+			scenario: "map containing error",
+			matcher:  matcher.MapContaining(map[any]any{matcher.Len(1): 1}),
+			actual:   map[int]int{1: 1},
+			expected: `expected contains entries {len is 1: 1}, error: len: value of type int does not have a length`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.scenario, func(t *testing.T) {
+			t.Parallel()
+
+			actual := matcher.Explain(tc.matcher, tc.actual)
+
+			require.NotNil(t, actual)
+			assert.Equal(t, tc.expected, actual.String())
+		})
+	}
+}
+
+func TestMap_Explain_Matched(t *testing.T) {
+	t.Parallel()
+
+	actual := map[string]int{"id": 1}
+
+	assert.Nil(t, matcher.Explain(matcher.HasEntry("id", 1), actual))
+	assert.Nil(t, matcher.Explain(matcher.KeysMatch(matcher.Len(1)), actual))
+	assert.Nil(t, matcher.Explain(matcher.MapContaining(map[any]any{"id": 1}), actual))
+}
@@ -0,0 +1,1166 @@
+package matcher
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// jsonOperatorPrefix is the prefix of the keys of the serialized matchers, like {"$regex": "^foo"}.
+const jsonOperatorPrefix = "$"
+
+var (
+	errJSONMatcherKeys    = errors.New("a matcher must have exactly one key")
+	errJSONInvalidLength  = errors.New("length must be a non-negative integer or a matcher")
+	errJSONInvalidBetween = errors.New("expected an array of lower and upper bounds")
+	errJSONInvalidOrdered = errors.New("expected a number or a string")
+	errJSONInvalidList    = errors.New("expected an array of matchers")
+	errJSONInvalidApprox  = errors.New("expected an array of the expectation and the tolerance")
+	errJSONInvalidWithin  = errors.New("expected an array of a time and a duration")
+	errJSONInvalidEntry   = errors.New("expected an array of a key and a value")
+	errJSONInvalidEntries = errors.New("expected an array of key and value pairs")
+	errJSONInvalidPath    = errors.New("expected an array of a path and a value")
+	errJSONInvalidText    = errors.New("expected a string")
+	errJSONFieldOptions   = errors.New("field matcher with options is not supported")
+	errJSONMatcherOptions = errors.New("json matcher with options or embedded matchers is not supported")
+	errJSONUnsupported    = errors.New("matcher does not support json")
+)
+
+// jsonUnmarshaler unmarshals the nested matchers.
+type jsonUnmarshaler func(data []byte) (Matcher, error)
+
+// jsonDecoder decodes the argument of a built-in matcher.
+type jsonDecoder func(unmarshal jsonUnmarshaler, arg json.RawMessage) (Matcher, error)
+
+// The operators of the built-in matchers, without the prefix. The logical ones are logicalOperatorAnd and
+// logicalOperatorOr.
+const (
+	operatorEqual          = "eq"
+	operatorRegex          = "regex"
+	operatorHasPrefix      = "prefix"
+	operatorHasSuffix      = "suffix"
+	operatorEqualFold      = "equalFold"
+	operatorWildcard       = "wildcard"
+	operatorLen            = "len"
+	operatorRuneLen        = "runeLen"
+	operatorAny            = "any"
+	operatorEmpty          = "empty"
+	operatorNotEmpty       = "notEmpty"
+	operatorNot            = "not"
+	operatorContains       = "contains"
+	operatorContainsAll    = "containsAll"
+	operatorContainsAny    = "containsAny"
+	operatorElementsMatch  = "elementsMatch"
+	operatorConsistOf      = "consistOf"
+	operatorEach           = "each"
+	operatorAnyElement     = "anyElement"
+	operatorHasKey         = "hasKey"
+	operatorHasValue       = "hasValue"
+	operatorHasEntry       = "hasEntry"
+	operatorKeysMatch      = "keysMatch"
+	operatorMapContaining  = "mapContaining"
+	operatorHasField       = "hasField"
+	operatorFields         = "fields"
+	operatorGreaterThan    = "gt"
+	operatorGreaterOrEqual = "gte"
+	operatorLessThan       = "lt"
+	operatorLessOrEqual    = "lte"
+	operatorBetween        = "between"
+	operatorInDelta        = "inDelta"
+	operatorInEpsilon      = "inEpsilon"
+	operatorWithinULP      = "withinULP"
+	operatorTimeEqual      = "timeEqual"
+	operatorBefore         = "before"
+	operatorAfter          = "after"
+	operatorWithinDuration = "withinDuration"
+	operatorTimeBetween    = "timeBetween"
+	operatorType           = "type"
+	operatorJSON           = "json"
+	operatorJSONPath       = "jsonPath"
+	operatorJSONPointer    = "jsonPointer"
+	operatorJSONSchema     = "jsonSchema"
+	operatorYAML           = "yaml"
+	operatorXML            = "xml"
+)
+
+// jsonDecoders are the built-in matchers, by operator.
+var jsonDecoders = map[string]jsonDecoder{
+	operatorEqual: func(_ jsonUnmarshaler, arg json.RawMessage) (Matcher, error) {
+		return decodeJSONEqual(arg)
+	},
+	operatorRegex: func(_ jsonUnmarshaler, arg json.RawMessage) (Matcher, error) {
+		var s string
+
+		if err := json.Unmarshal(arg, &s); err != nil {
+			return nil, err
+		}
+
+		r, err := regexp.Compile(s)
+		if err != nil {
+			return nil, err
+		}
+
+		return Regex(r), nil
+	},
+	operatorHasPrefix:     jsonStringDecoder(HasPrefix[string]),
+	operatorHasSuffix:     jsonStringDecoder(HasSuffix[string]),
+	operatorEqualFold:     jsonStringDecoder(EqualFold[string]),
+	operatorWildcard:      jsonStringDecoder(Wildcard[string]),
+	operatorLen:           jsonLenDecoder(false),
+	operatorRuneLen:       jsonLenDecoder(true),
+	operatorAny:           jsonConstantDecoder(Any),
+	operatorEmpty:         jsonConstantDecoder(emptyMatcher{}),
+	operatorNotEmpty:      jsonConstantDecoder(notEmptyMatcher{}),
+	logicalOperatorAnd:    jsonListDecoder(And),
+	logicalOperatorOr:     jsonListDecoder(Or),
+	operatorNot:           jsonNestedDecoder(Not),
+	operatorContains:      jsonElementDecoder(Contains),
+	operatorContainsAll:   jsonElementsDecoder(ContainsAll),
+	operatorContainsAny:   jsonElementsDecoder(ContainsAny),
+	operatorElementsMatch: jsonElementsDecoder(ElementsMatch),
+	operatorConsistOf:     jsonElementsDecoder(ConsistOf),
+	operatorEach:          jsonNestedDecoder(Each),
+	operatorAnyElement:    jsonNestedDecoder(AnyElement),
+	operatorHasKey:        jsonElementDecoder(HasKey),
+	operatorHasValue:      jsonElementDecoder(HasValue),
+	operatorHasEntry: func(unmarshal jsonUnmarshaler, arg json.RawMessage) (Matcher, error) {
+		items, err := decodeJSONTuple(arg, errJSONInvalidEntry)
+		if err != nil {
+			return nil, err
+		}
+
+		key, err := decodeJSONElement(unmarshal, items[0])
+		if err != nil {
+			return nil, err
+		}
+
+		value, err := decodeJSONElement(unmarshal, items[1])
+		if err != nil {
+			return nil, err
+		}
+
+		return HasEntry(key, value), nil
+	},
+	operatorKeysMatch:     jsonNestedDecoder(KeysMatch),
+	operatorMapContaining: decodeJSONMapContaining,
+	operatorHasField: func(unmarshal jsonUnmarshaler, arg json.RawMessage) (Matcher, error) {
+		path, value, err := decodeJSONPathValue(unmarshal, arg)
+		if err != nil {
+			return nil, err
+		}
+
+		return HasField(path, value), nil
+	},
+	operatorFields: func(unmarshal jsonUnmarshaler, arg json.RawMessage) (Matcher, error) {
+		var obj map[string]json.RawMessage
+
+		if err := json.Unmarshal(arg, &obj); err != nil {
+			return nil, err
+		}
+
+		expected := make(map[string]any, len(obj))
+
+		for path, item := range obj {
+			v, err := decodeJSONElement(unmarshal, item)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", path, err)
+			}
+
+			expected[path] = v
+		}
+
+		return Fields(expected), nil
+	},
+	operatorGreaterThan:    jsonCompareDecoder(compareOperatorGreaterThan),
+	operatorGreaterOrEqual: jsonCompareDecoder(compareOperatorGreaterOrEqual),
+	operatorLessThan:       jsonCompareDecoder(compareOperatorLessThan),
+	operatorLessOrEqual:    jsonCompareDecoder(compareOperatorLessOrEqual),
+	operatorBetween: func(_ jsonUnmarshaler, arg json.RawMessage) (Matcher, error) {
+		var bounds []any
+
+		if err := json.Unmarshal(arg, &bounds); err != nil {
+			return nil, err
+		}
+
+		if len(bounds) != 2 || !isJSONOrdered(bounds[0]) || !isJSONOrdered(bounds[1]) {
+			return nil, errJSONInvalidBetween
+		}
+
+		return betweenMatcher{lower: bounds[0], upper: bounds[1]}, nil
+	},
+	operatorInDelta:   jsonApproxDecoder(InDelta),
+	operatorInEpsilon: jsonApproxDecoder(InEpsilon),
+	operatorWithinULP: jsonApproxDecoder(WithinULP),
+	operatorTimeEqual: jsonTimeDecoder(TimeEqual),
+	operatorBefore:    jsonTimeDecoder(Before),
+	operatorAfter:     jsonTimeDecoder(After),
+	operatorWithinDuration: func(_ jsonUnmarshaler, arg json.RawMessage) (Matcher, error) {
+		items, err := decodeJSONTuple(arg, errJSONInvalidWithin)
+		if err != nil {
+			return nil, err
+		}
+
+		var (
+			t time.Time
+			d string
+		)
+
+		if err := json.Unmarshal(items[0], &t); err != nil {
+			return nil, err
+		}
+
+		if err := json.Unmarshal(items[1], &d); err != nil {
+			return nil, errJSONInvalidWithin
+		}
+
+		delta, err := time.ParseDuration(d)
+		if err != nil {
+			return nil, err
+		}
+
+		return WithinDuration(t, delta), nil
+	},
+	operatorTimeBetween: func(_ jsonUnmarshaler, arg json.RawMessage) (Matcher, error) {
+		var bounds []time.Time
+
+		if err := json.Unmarshal(arg, &bounds); err != nil {
+			return nil, err
+		}
+
+		if len(bounds) != 2 {
+			return nil, errJSONInvalidBetween
+		}
+
+		return TimeBetween(bounds[0], bounds[1]), nil
+	},
+	operatorType: func(_ jsonUnmarshaler, arg json.RawMessage) (Matcher, error) {
+		var name string
+
+		if err := json.Unmarshal(arg, &name); err != nil {
+			return nil, err
+		}
+
+		return typeMatcher{name: name}, nil
+	},
+	operatorJSON: func(_ jsonUnmarshaler, arg json.RawMessage) (Matcher, error) {
+		return JSON(string(arg)), nil
+	},
+	operatorJSONPath: func(unmarshal jsonUnmarshaler, arg json.RawMessage) (Matcher, error) {
+		path, value, err := decodeJSONPathValue(unmarshal, arg)
+		if err != nil {
+			return nil, err
+		}
+
+		if _, err := parseJSONPath(path); err != nil {
+			return nil, err
+		}
+
+		return JSONPath(path, value), nil
+	},
+	operatorJSONPointer: func(unmarshal jsonUnmarshaler, arg json.RawMessage) (Matcher, error) {
+		pointer, value, err := decodeJSONPathValue(unmarshal, arg)
+		if err != nil {
+			return nil, err
+		}
+
+		if _, err := parseJSONPointer(pointer); err != nil {
+			return nil, err
+		}
+
+		return JSONPointer(pointer, value), nil
+	},
+	operatorJSONSchema: func(_ jsonUnmarshaler, arg json.RawMessage) (Matcher, error) {
+		var path string
+
+		if err := json.Unmarshal(arg, &path); err == nil {
+			return newJSONSchemaMatcher(path)
+		}
+
+		return newJSONSchemaMatcher([]byte(arg))
+	},
+	operatorYAML: func(_ jsonUnmarshaler, arg json.RawMessage) (Matcher, error) {
+		var doc string
+
+		if err := json.Unmarshal(arg, &doc); err != nil {
+			return nil, errJSONInvalidText
+		}
+
+		if _, err := yamlVal(doc); err != nil {
+			return nil, err
+		}
+
+		return YAML(doc), nil
+	},
+	operatorXML: func(_ jsonUnmarshaler, arg json.RawMessage) (Matcher, error) {
+		var doc string
+
+		if err := json.Unmarshal(arg, &doc); err != nil {
+			return nil, errJSONInvalidText
+		}
+
+		if _, err := parseXML([]byte(doc)); err != nil {
+			return nil, err
+		}
+
+		return XML(doc), nil
+	},
+}
+
+// jsonContainsOperators are the operators of the contains matchers.
+var jsonContainsOperators = map[containsQuantifier]string{
+	containsQuantifierOne: operatorContains,
+	containsQuantifierAll: operatorContainsAll,
+	containsQuantifierAny: operatorContainsAny,
+}
+
+// jsonPathOperators are the operators of the json path matchers, by kind.
+var jsonPathOperators = map[string]string{
+	"json path":    operatorJSONPath,
+	"json pointer": operatorJSONPointer,
+}
+
+// jsonCompareOperators are the operators of the compare matchers.
+var jsonCompareOperators = map[compareOperator]string{
+	compareOperatorGreaterThan:    operatorGreaterThan,
+	compareOperatorGreaterOrEqual: operatorGreaterOrEqual,
+	compareOperatorLessThan:       operatorLessThan,
+	compareOperatorLessOrEqual:    operatorLessOrEqual,
+}
+
+func jsonStringDecoder(build func(string) Matcher) jsonDecoder {
+	return func(_ jsonUnmarshaler, arg json.RawMessage) (Matcher, error) {
+		var s string
+
+		if err := json.Unmarshal(arg, &s); err != nil {
+			return nil, err
+		}
+
+		return build(s), nil
+	}
+}
+
+func jsonConstantDecoder(m Matcher) jsonDecoder {
+	return func(jsonUnmarshaler, json.RawMessage) (Matcher, error) {
+		return m, nil
+	}
+}
+
+func jsonListDecoder(build func(...any) Matcher) jsonDecoder {
+	return func(unmarshal jsonUnmarshaler, arg json.RawMessage) (Matcher, error) {
+		var items []json.RawMessage
+
+		if err := json.Unmarshal(arg, &items); err != nil {
+			return nil, errJSONInvalidList
+		}
+
+		matchers := make([]any, len(items))
+
+		for i, item := range items {
+			m, err := unmarshal(item)
+			if err != nil {
+				return nil, fmt.Errorf("[%d]: %w", i, err)
+			}
+
+			matchers[i] = m
+		}
+
+		return build(matchers...), nil
+	}
+}
+
+func jsonNestedDecoder(build func(any) Matcher) jsonDecoder {
+	return func(unmarshal jsonUnmarshaler, arg json.RawMessage) (Matcher, error) {
+		m, err := unmarshal(arg)
+		if err != nil {
+			return nil, err
+		}
+
+		return build(m), nil
+	}
+}
+
+func jsonElementDecoder(build func(any) Matcher) jsonDecoder {
+	return func(unmarshal jsonUnmarshaler, arg json.RawMessage) (Matcher, error) {
+		e, err := decodeJSONElement(unmarshal, arg)
+		if err != nil {
+			return nil, err
+		}
+
+		return build(e), nil
+	}
+}
+
+func jsonElementsDecoder(build func(...any) Matcher) jsonDecoder {
+	return func(unmarshal jsonUnmarshaler, arg json.RawMessage) (Matcher, error) {
+		var items []json.RawMessage
+
+		if err := json.Unmarshal(arg, &items); err != nil {
+			return nil, errJSONInvalidList
+		}
+
+		elements := make([]any, len(items))
+
+		for i, item := range items {
+			e, err := decodeJSONElement(unmarshal, item)
+			if err != nil {
+				return nil, fmt.Errorf("[%d]: %w", i, err)
+			}
+
+			elements[i] = e
+		}
+
+		return build(elements...), nil
+	}
+}
+
+// decodeJSONElement decodes an expectation of Contains. A string is kept as is, so that it is still matched as a
+// substring, and the other values are unmarshaled as matchers.
+func decodeJSONElement(unmarshal jsonUnmarshaler, arg json.RawMessage) (any, error) {
+	var s string
+
+	if err := json.Unmarshal(arg, &s); err == nil {
+		return s, nil
+	}
+
+	return unmarshal(arg)
+}
+
+// decodeJSONTuple decodes an array of two items.
+func decodeJSONTuple(arg json.RawMessage, errInvalid error) ([]json.RawMessage, error) {
+	var items []json.RawMessage
+
+	if err := json.Unmarshal(arg, &items); err != nil || len(items) != 2 {
+		return nil, errInvalid
+	}
+
+	return items, nil
+}
+
+// decodeJSONPathValue decodes the path and the expectation of HasField, JSONPath and JSONPointer.
+func decodeJSONPathValue(unmarshal jsonUnmarshaler, arg json.RawMessage) (string, any, error) {
+	items, err := decodeJSONTuple(arg, errJSONInvalidPath)
+	if err != nil {
+		return "", nil, err
+	}
+
+	var path string
+
+	if err := json.Unmarshal(items[0], &path); err != nil {
+		return "", nil, errJSONInvalidPath
+	}
+
+	value, err := decodeJSONElement(unmarshal, items[1])
+	if err != nil {
+		return "", nil, err
+	}
+
+	return path, value, nil
+}
+
+// decodeJSONMapContaining decodes the entries of MapContaining, in order. The matcher is created without MapContaining,
+// because the keys could be matchers that are not comparable.
+func decodeJSONMapContaining(unmarshal jsonUnmarshaler, arg json.RawMessage) (Matcher, error) {
+	var entries []json.RawMessage
+
+	if err := json.Unmarshal(arg, &entries); err != nil {
+		return nil, errJSONInvalidEntries
+	}
+
+	m := mapContainingMatcher{
+		keys:   make([]element, len(entries)),
+		values: make([]Matcher, len(entries)),
+	}
+
+	for i, entry := range entries {
+		items, err := decodeJSONTuple(entry, errJSONInvalidEntries)
+		if err != nil {
+			return nil, err
+		}
+
+		key, err := decodeJSONElement(unmarshal, items[0])
+		if err != nil {
+			return nil, fmt.Errorf("[%d]: %w", i, err)
+		}
+
+		value, err := unmarshal(items[1])
+		if err != nil {
+			return nil, fmt.Errorf("[%d]: %w", i, err)
+		}
+
+		m.keys[i] = element{value: key, matcher: makeNestedMatcher(key)}
+		m.values[i] = makeNestedMatcher(value)
+	}
+
+	return m, nil
+}
+
+// decodeJSONEqual decodes the expectation of Equal. The expectation is compared with the JSON representation of the
+// actual, so that Equal(3) still matches int(3), and Equal([]int{1, 2}) still matches []int{1, 2}, after a round trip.
+func decodeJSONEqual(data []byte) (Matcher, error) {
+	var v any
+
+	if err := json.Unmarshal(data, &v); err != nil {
+		return nil, err
+	}
+
+	v, _ = decodeJSONValue(data) //nolint: errcheck
+
+	return jsonValueMatcher{expected: v}, nil
+}
+
+// decodeJSONValue decodes a JSON document, with the numbers as json.Number.
+func decodeJSONValue(data []byte) (any, error) {
+	d := json.NewDecoder(bytes.NewReader(data))
+	d.UseNumber()
+
+	var v any
+
+	if err := d.Decode(&v); err != nil {
+		return nil, err
+	}
+
+	return v, nil
+}
+
+// equalJSONValue compares two values decoded by decodeJSONValue. The numbers are compared by value, so that 1 equals
+// 1.0.
+func equalJSONValue(x, y any) bool {
+	switch x := x.(type) {
+	case json.Number:
+		c, ok := compareVal(x, y)
+
+		return ok && c == 0
+
+	case map[string]any:
+		y, ok := y.(map[string]any)
+		if !ok || len(x) != len(y) {
+			return false
+		}
+
+		for k, v := range x {
+			if w, ok := y[k]; !ok || !equalJSONValue(v, w) {
+				return false
+			}
+		}
+
+		return true
+
+	case []any:
+		y, ok := y.([]any)
+		if !ok || len(x) != len(y) {
+			return false
+		}
+
+		for i := range x {
+			if !equalJSONValue(x[i], y[i]) {
+				return false
+			}
+		}
+
+		return true
+	}
+
+	return x == y
+}
+
+func jsonLenDecoder(runes bool) jsonDecoder {
+	return func(unmarshal jsonUnmarshaler, arg json.RawMessage) (Matcher, error) {
+		if !isJSONObject(arg) {
+			var n float64
+
+			if err := json.Unmarshal(arg, &n); err != nil || n < 0 || n != math.Trunc(n) {
+				return nil, errJSONInvalidLength
+			}
+
+			return lenMatcher{matcher: Equal(int(n)), runes: runes}, nil
+		}
+
+		m, err := unmarshal(arg)
+		if err != nil {
+			return nil, err
+		}
+
+		return lenMatcher{matcher: makeNestedMatcher(m), runes: runes}, nil
+	}
+}
+
+func jsonCompareDecoder(operator compareOperator) jsonDecoder {
+	return func(_ jsonUnmarshaler, arg json.RawMessage) (Matcher, error) {
+		var v any
+
+		if err := json.Unmarshal(arg, &v); err != nil {
+			return nil, err
+		}
+
+		if !isJSONOrdered(v) {
+			return nil, errJSONInvalidOrdered
+		}
+
+		return compareMatcher{expected: v, operator: operator}, nil
+	}
+}
+
+func jsonApproxDecoder[T float64 | uint64](build func(expected any, tolerance T) Matcher) jsonDecoder {
+	return func(_ jsonUnmarshaler, arg json.RawMessage) (Matcher, error) {
+		items, err := decodeJSONTuple(arg, errJSONInvalidApprox)
+		if err != nil {
+			return nil, err
+		}
+
+		var (
+			expected  any
+			tolerance T
+		)
+
+		if err := json.Unmarshal(items[0], &expected); err != nil {
+			return nil, err
+		}
+
+		if err := validateFloats(reflect.ValueOf(expected)); err != nil {
+			return nil, err
+		}
+
+		if err := json.Unmarshal(items[1], &tolerance); err != nil {
+			return nil, errJSONInvalidApprox
+		}
+
+		return build(expected, tolerance), nil
+	}
+}
+
+func jsonTimeDecoder(build func(time.Time) Matcher) jsonDecoder {
+	return func(_ jsonUnmarshaler, arg json.RawMessage) (Matcher, error) {
+		var t time.Time
+
+		if err := json.Unmarshal(arg, &t); err != nil {
+			return nil, err
+		}
+
+		return build(t), nil
+	}
+}
+
+func isJSONOrdered(v any) bool {
+	switch v.(type) {
+	case float64, string:
+		return true
+	}
+
+	return false
+}
+
+func isJSONObject(data []byte) bool {
+	return bytes.HasPrefix(bytes.TrimSpace(data), []byte("{"))
+}
+
+// marshalJSONOperator marshals a matcher as an object with one key, which is the operator.
+func marshalJSONOperator(operator string, arg any) ([]byte, error) {
+	v, err := json.Marshal(arg)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", operator, err)
+	}
+
+	return json.Marshal(map[string]json.RawMessage{jsonOperatorPrefix + operator: v})
+}
+
+// marshalJSONMatchers marshals the nested matchers.
+func marshalJSONMatchers(matchers []Matcher) ([]json.RawMessage, error) {
+	result := make([]json.RawMessage, len(matchers))
+
+	for i, m := range matchers {
+		b, err := MarshalJSON(m)
+		if err != nil {
+			return nil, err
+		}
+
+		result[i] = b
+	}
+
+	return result, nil
+}
+
+// marshalJSONElement marshals an expectation of an element. The values are marshaled as they are, or by their JSON
+// representation for the json path matchers, and the others as matchers.
+func marshalJSONElement(e element) ([]byte, error) {
+	switch v := e.value.(type) {
+	case jsonValueMatcher:
+		return json.Marshal(v.expected)
+
+	case Matcher:
+		return MarshalJSON(v)
+	}
+
+	if m, ok := e.matcher.(equalMatcher); ok {
+		return json.Marshal(m.expected)
+	}
+
+	return MarshalJSON(e.matcher)
+}
+
+// marshalJSONElements marshals the expectations of the elements.
+func marshalJSONElements(operator string, elements []element) ([]json.RawMessage, error) {
+	result := make([]json.RawMessage, len(elements))
+
+	for i, e := range elements {
+		b, err := marshalJSONElement(e)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", operator, err)
+		}
+
+		result[i] = b
+	}
+
+	return result, nil
+}
+
+// MarshalJSON marshals the expectation.
+func (m equalMatcher) MarshalJSON() ([]byte, error) {
+	return marshalJSONOperator(operatorEqual, m.expected)
+}
+
+// MarshalJSON marshals the expectation.
+func (m jsonValueMatcher) MarshalJSON() ([]byte, error) {
+	return marshalJSONOperator(operatorEqual, m.expected)
+}
+
+// MarshalJSON marshals the expectation.
+func (m regexMatcher) MarshalJSON() ([]byte, error) {
+	return marshalJSONOperator(operatorRegex, m.regexp.String())
+}
+
+// MarshalJSON marshals the expectation.
+func (m stringMatcher) MarshalJSON() ([]byte, error) {
+	return marshalJSONOperator(m.operator, m.value)
+}
+
+// MarshalJSON marshals the expectation. A length that is a number is marshaled as is.
+func (m lenMatcher) MarshalJSON() ([]byte, error) {
+	operator := operatorLen
+	if m.runes {
+		operator = operatorRuneLen
+	}
+
+	if e, ok := m.matcher.(equalMatcher); ok {
+		if n, ok := e.expected.(int); ok {
+			return marshalJSONOperator(operator, n)
+		}
+	}
+
+	b, err := MarshalJSON(m.matcher)
+	if err != nil {
+		return nil, err
+	}
+
+	return marshalJSONOperator(operator, json.RawMessage(b))
+}
+
+// MarshalJSON marshals the expectation. The expectations that are not matchers, or are numbers decoded from JSON, are
+// marshaled as they are.
+func (m containsMatcher) MarshalJSON() ([]byte, error) {
+	operator := jsonContainsOperators[m.quantifier]
+
+	elements, err := marshalJSONElements(operator, m.elements)
+	if err != nil {
+		return nil, err
+	}
+
+	if m.quantifier == containsQuantifierOne {
+		return marshalJSONOperator(operator, elements[0])
+	}
+
+	return marshalJSONOperator(operator, elements)
+}
+
+// MarshalJSON marshals the expectation. The expectations that are not matchers are marshaled as they are.
+func (m elementsMatcher) MarshalJSON() ([]byte, error) {
+	operator := operatorElementsMatch
+	if m.ordered {
+		operator = operatorConsistOf
+	}
+
+	elements, err := marshalJSONElements(operator, m.elements)
+	if err != nil {
+		return nil, err
+	}
+
+	return marshalJSONOperator(operator, elements)
+}
+
+// MarshalJSON marshals the expectation.
+func (m eachMatcher) MarshalJSON() ([]byte, error) {
+	operator := operatorEach
+	if m.any {
+		operator = operatorAnyElement
+	}
+
+	b, err := MarshalJSON(m.matcher)
+	if err != nil {
+		return nil, err
+	}
+
+	return marshalJSONOperator(operator, json.RawMessage(b))
+}
+
+// MarshalJSON marshals the expectation. The expectations that are not matchers are marshaled as they are.
+func (m entryMatcher) MarshalJSON() ([]byte, error) {
+	var elements []element
+
+	switch m.operator {
+	case operatorHasKey:
+		elements = []element{m.key}
+
+	case operatorHasValue:
+		elements = []element{m.value}
+
+	default:
+		elements = []element{m.key, m.value}
+	}
+
+	items, err := marshalJSONElements(m.operator, elements)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(items) == 1 {
+		return marshalJSONOperator(m.operator, items[0])
+	}
+
+	return marshalJSONOperator(m.operator, items)
+}
+
+// MarshalJSON marshals the expectation.
+func (m keysMatcher) MarshalJSON() ([]byte, error) {
+	b, err := MarshalJSON(m.matcher)
+	if err != nil {
+		return nil, err
+	}
+
+	return marshalJSONOperator(operatorKeysMatch, json.RawMessage(b))
+}
+
+// MarshalJSON marshals the entries as an array of key and value pairs, because the keys could be matchers.
+func (m mapContainingMatcher) MarshalJSON() ([]byte, error) {
+	keys, err := marshalJSONElements(operatorMapContaining, m.keys)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([][]json.RawMessage, len(m.keys))
+
+	for i, k := range keys {
+		v, err := MarshalJSON(m.values[i])
+		if err != nil {
+			return nil, err
+		}
+
+		entries[i] = []json.RawMessage{k, v}
+	}
+
+	return marshalJSONOperator(operatorMapContaining, entries)
+}
+
+// MarshalJSON marshals the expectation. The matchers with options are not supported.
+func (m fieldsMatcher) MarshalJSON() ([]byte, error) {
+	if m.unexported {
+		return nil, errJSONFieldOptions
+	}
+
+	values, err := marshalJSONElements(m.operator, m.values)
+	if err != nil {
+		return nil, err
+	}
+
+	if m.operator == operatorHasField {
+		return marshalJSONOperator(m.operator, []any{m.paths[0], values[0]})
+	}
+
+	fields := make(map[string]json.RawMessage, len(m.paths))
+
+	for i, path := range m.paths {
+		fields[path] = values[i]
+	}
+
+	return marshalJSONOperator(m.operator, fields)
+}
+
+// MarshalJSON marshals the expectation. The values that are not matchers are marshaled as they are.
+func (m jsonPathMatcher) MarshalJSON() ([]byte, error) {
+	operator := jsonPathOperators[m.kind]
+
+	b, err := marshalJSONElement(m.value)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", operator, err)
+	}
+
+	return marshalJSONOperator(operator, []any{m.path, json.RawMessage(b)})
+}
+
+// MarshalJSON marshals the schema, or the path to the schema.
+func (m jsonSchemaMatcher) MarshalJSON() ([]byte, error) {
+	return marshalJSONOperator(operatorJSONSchema, m.source)
+}
+
+// MarshalJSON marshals the document as a string.
+func (m yamlMatcher) MarshalJSON() ([]byte, error) {
+	return marshalJSONOperator(operatorYAML, m.expected)
+}
+
+// MarshalJSON marshals the document as a string.
+func (m xmlMatcher) MarshalJSON() ([]byte, error) {
+	return marshalJSONOperator(operatorXML, m.expected)
+}
+
+// MarshalJSON marshals the name of the type.
+func (m typeMatcher) MarshalJSON() ([]byte, error) {
+	return marshalJSONOperator(operatorType, m.name)
+}
+
+// MarshalJSON marshals the expectation. The durations are marshaled as strings, like "1.5s".
+func (m timeMatcher) MarshalJSON() ([]byte, error) {
+	return marshalJSONOperator(m.operator, m.value)
+}
+
+// MarshalJSON marshals the expectation and the tolerance.
+func (m approxMatcher) MarshalJSON() ([]byte, error) {
+	return marshalJSONOperator(m.operator, m.value)
+}
+
+// MarshalJSON marshals the expectation.
+func (anyMatcher) MarshalJSON() ([]byte, error) {
+	return marshalJSONOperator(operatorAny, true)
+}
+
+// MarshalJSON marshals the expectation.
+func (emptyMatcher) MarshalJSON() ([]byte, error) {
+	return marshalJSONOperator(operatorEmpty, true)
+}
+
+// MarshalJSON marshals the expectation.
+func (notEmptyMatcher) MarshalJSON() ([]byte, error) {
+	return marshalJSONOperator(operatorNotEmpty, true)
+}
+
+// MarshalJSON marshals the expectation.
+func (m *binaryLogicalMatcher) MarshalJSON() ([]byte, error) {
+	matchers, err := marshalJSONMatchers(m.matchers)
+	if err != nil {
+		return nil, err
+	}
+
+	return marshalJSONOperator(string(m.operator), matchers)
+}
+
+// MarshalJSON marshals the expectation.
+func (m notLogicalMatcher) MarshalJSON() ([]byte, error) {
+	b, err := MarshalJSON(m.matcher)
+	if err != nil {
+		return nil, err
+	}
+
+	return marshalJSONOperator(operatorNot, json.RawMessage(b))
+}
+
+// MarshalJSON marshals the expectation.
+func (m compareMatcher) MarshalJSON() ([]byte, error) {
+	return marshalJSONOperator(jsonCompareOperators[m.operator], m.expected)
+}
+
+// MarshalJSON marshals the expectation.
+func (m betweenMatcher) MarshalJSON() ([]byte, error) {
+	return marshalJSONOperator(operatorBetween, []any{m.lower, m.upper})
+}
+
+// MarshalJSON marshals the expectation. The matchers with options or embedded matchers are not supported.
+func (m jsonMatcher) MarshalJSON() ([]byte, error) {
+	if !m.config.isDefault() {
+		return nil, errJSONMatcherOptions
+	}
+
+	return marshalJSONOperator(operatorJSON, json.RawMessage(m.expected))
+}
+
+// MarshalJSON marshals the expectation of the matcher returned by the callback.
+func (m Callback) MarshalJSON() ([]byte, error) {
+	return MarshalJSON(m())
+}
+
+var (
+	_ Matcher   = (*jsonValueMatcher)(nil)
+	_ Explainer = (*jsonValueMatcher)(nil)
+)
+
+// jsonValueMatcher matches a value that is unmarshaled from JSON, by the JSON representation of the actual.
+type jsonValueMatcher struct {
+	expected any
+}
+
+// Match determines if the actual is expected.
+func (m jsonValueMatcher) Match(actual any) (bool, error) {
+	b, err := json.Marshal(actual)
+	if err != nil {
+		return false, nil //nolint: nilerr
+	}
+
+	v, err := decodeJSONValue(b)
+	if err != nil {
+		return false, nil //nolint: nilerr
+	}
+
+	return equalJSONValue(m.expected, v), nil
+}
+
+// Expected returns the expectation.
+func (m jsonValueMatcher) Expected() string {
+	if v := strVal(m.expected); v != nil {
+		return *v
+	}
+
+	return fmt.Sprintf("%+v", m.expected)
+}
+
+// Explain explains why the actual is not expected.
+func (m jsonValueMatcher) Explain(actual any) *Mismatch {
+	return explain(m, actual, "")
+}
+
+func (m jsonValueMatcher) Format(s fmt.State, _ rune) {
+	_, _ = fmt.Fprintf(s, "<%s>", m.Expected()) //nolint: errcheck
+}
+
+var (
+	_ Matcher   = (*namedMatcher)(nil)
+	_ Explainer = (*namedMatcher)(nil)
+)
+
+// namedMatcher is a custom matcher that is serialized by name.
+type namedMatcher struct {
+	name    string
+	arg     any
+	matcher Matcher
+}
+
+// Match determines if the actual is expected.
+func (m namedMatcher) Match(actual any) (bool, error) {
+	return m.matcher.Match(actual)
+}
+
+// Expected returns the expectation.
+func (m namedMatcher) Expected() string {
+	return m.matcher.Expected()
+}
+
+// Explain explains why the actual is not expected.
+func (m namedMatcher) Explain(actual any) *Mismatch {
+	return Explain(m.matcher, actual)
+}
+
+// MarshalJSON marshals the name and the argument of the matcher.
+func (m namedMatcher) MarshalJSON() ([]byte, error) {
+	return marshalJSONOperator(m.name, m.arg)
+}
+
+func (m namedMatcher) Format(s fmt.State, _ rune) {
+	_, _ = fmt.Fprintf(s, "<%s>", m.Expected()) //nolint: errcheck
+}
+
+// Named gives a name to a custom matcher, like the ones created by Func, so it is marshaled to JSON as
+// {"$<name>": <arg>}. Use a Registry to unmarshal it, with a factory or a decoder registered by the name.
+func Named(name string, arg any, m Matcher) Matcher {
+	return namedMatcher{name: name, arg: arg, matcher: m}
+}
+
+// MarshalJSON marshals a matcher to JSON. The built-in matchers are marshaled as objects with one key, which is the
+// operator prefixed by "$", like {"$regex": "^foo"}, {"$and": [...]} or {"$len": 3}. The custom matchers must be
+// created by Named(), or implement json.Marshaler.
+//
+// These built-in matchers are not supported: Func, Untyped, JSON with options or embedded matchers, and HasField and
+// Fields with WithUnexportedFields(). The times are marshaled as RFC3339 strings and the durations like "1.5s". The
+// types of IsType and SameTypeAs are marshaled by name, like {"$type": "*time.Time"}, so an unmarshaled type matcher
+// compares the names of the types. After a round trip, the expectations of InDelta, InEpsilon and WithinULP are
+// float64, so WithinULP counts the distance in float64.
+func MarshalJSON(m Matcher) ([]byte, error) {
+	if v, ok := m.(json.Marshaler); ok {
+		return v.MarshalJSON()
+	}
+
+	return nil, fmt.Errorf("%w: %T %s", errJSONUnsupported, m, m.Expected())
+}
+
+// ParseJSON unmarshals a matcher marshaled by MarshalJSON, with the built-in matchers only. A value that is not
+// an object with one "$" key is matched like $eq, by the JSON representation of the actual, so that Equal([]int{1, 2})
+// still matches []int{1, 2} after a round trip, and the numbers match the numbers of any type with the same value. The
+// numbers in the arguments of the other matchers are float64, like the ones decoded by encoding/json.
+func ParseJSON(data []byte) (Matcher, error) {
+	var r Registry
+
+	return r.Unmarshal(data)
+}
+
+var (
+	_ json.Marshaler   = (*JSONMatcher)(nil)
+	_ json.Unmarshaler = (*JSONMatcher)(nil)
+)
+
+// JSONMatcher is a matcher that is marshaled by MarshalJSON and unmarshaled by ParseJSON, so it could be a field of a
+// struct that is encoded by encoding/json, like a recorded expectation:
+//
+//	type Expectation struct {
+//		Method string              `json:"method"`
+//		Body   matcher.JSONMatcher `json:"body"`
+//	}
+//
+// Only the built-in matchers are unmarshaled. Use Registry.Unmarshal() for the custom matchers. A null is a nil
+// matcher.
+type JSONMatcher struct {
+	Matcher
+}
+
+// MarshalJSON marshals the matcher.
+func (m JSONMatcher) MarshalJSON() ([]byte, error) {
+	if m.Matcher == nil {
+		return []byte("null"), nil
+	}
+
+	return MarshalJSON(m.Matcher)
+}
+
+// UnmarshalJSON unmarshals the matcher.
+func (m *JSONMatcher) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(bytes.TrimSpace(data), []byte("null")) {
+		m.Matcher = nil
+
+		return nil
+	}
+
+	matcher, err := ParseJSON(data)
+	if err != nil {
+		return err
+	}
+
+	m.Matcher = matcher
+
+	return nil
+}
+
+// jsonOperator returns the operator of a matcher object. The ok flag is false if the object is a value.
+func jsonOperator(obj map[string]json.RawMessage) (name string, arg json.RawMessage, ok bool, _ error) {
+	for k, v := range obj {
+		if !strings.HasPrefix(k, jsonOperatorPrefix) {
+			continue
+		}
+
+		if len(obj) != 1 {
+			return "", nil, false, errJSONMatcherKeys
+		}
+
+		return strings.TrimPrefix(k, jsonOperatorPrefix), v, true, nil
+	}
+
+	return "", nil, false, nil
+}
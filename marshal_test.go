@@ -0,0 +1,952 @@
+package matcher_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.nhat.io/matcher/v3"
+)
+
+var marshalTestTime = time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+
+func TestMarshalJSON(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		scenario string
+		matcher  matcher.Matcher
+		expected string
+	}{
+		{
+			scenario: "equal",
+			matcher:  matcher.Equal("foo"),
+			expected: `{"$eq": "foo"}`,
+		},
+		{
+			scenario: "equal - struct",
+			matcher:  matcher.Equal(map[string]int{"id": 1}),
+			expected: `{"$eq": {"id": 1}}`,
+		},
+		{
+			scenario: "regex",
+			matcher:  matcher.Regex("^foo"),
+			expected: `{"$regex": "^foo"}`,
+		},
+		{
+			scenario: "wildcard",
+			matcher:  matcher.Wildcard("foo*"),
+			expected: `{"$regex": "^foo.*$"}`,
+		},
+		{
+			scenario: "prefix",
+			matcher:  matcher.HasPrefix("foo"),
+			expected: `{"$prefix": "foo"}`,
+		},
+		{
+			scenario: "suffix",
+			matcher:  matcher.HasSuffix("foo"),
+			expected: `{"$suffix": "foo"}`,
+		},
+		{
+			scenario: "equal fold",
+			matcher:  matcher.EqualFold("foo"),
+			expected: `{"$equalFold": "foo"}`,
+		},
+		{
+			scenario: "len",
+			matcher:  matcher.Len(3),
+			expected: `{"$len": 3}`,
+		},
+		{
+			scenario: "len matches",
+			matcher:  matcher.LenMatches(matcher.GreaterThan(2)),
+			expected: `{"$len": {"$gt": 2}}`,
+		},
+		{
+			scenario: "rune len",
+			matcher:  matcher.RuneLen(3),
+			expected: `{"$runeLen": 3}`,
+		},
+		{
+			scenario: "any",
+			matcher:  matcher.Any,
+			expected: `{"$any": true}`,
+		},
+		{
+			scenario: "empty",
+			matcher:  matcher.IsEmpty(),
+			expected: `{"$empty": true}`,
+		},
+		{
+			scenario: "not empty",
+			matcher:  matcher.IsNotEmpty(),
+			expected: `{"$notEmpty": true}`,
+		},
+		{
+			scenario: "and",
+			matcher:  matcher.And("foo", matcher.Len(3)),
+			expected: `{"$and": [{"$eq": "foo"}, {"$len": 3}]}`,
+		},
+		{
+			scenario: "or",
+			matcher:  matcher.Or(matcher.IsEmpty(), regexp.MustCompile("^a")),
+			expected: `{"$or": [{"$empty": true}, {"$regex": "^a"}]}`,
+		},
+		{
+			scenario: "not",
+			matcher:  matcher.Not(matcher.IsEmpty()),
+			expected: `{"$not": {"$empty": true}}`,
+		},
+		{
+			scenario: "compare",
+			matcher:  matcher.And(matcher.GreaterOrEqual(1), matcher.LessThan(2.5)),
+			expected: `{"$and": [{"$gte": 1}, {"$lt": 2.5}]}`,
+		},
+		{
+			scenario: "between",
+			matcher:  matcher.Between("a", "c"),
+			expected: `{"$between": ["a", "c"]}`,
+		},
+		{
+			scenario: "json",
+			matcher:  matcher.JSON(`{"id": "<ignore-diff>"}`),
+			expected: `{"$json": {"id": "<ignore-diff>"}}`,
+		},
+		{
+			scenario: "contains",
+			matcher:  matcher.Contains("foo"),
+			expected: `{"$contains": "foo"}`,
+		},
+		{
+			scenario: "contains all",
+			matcher:  matcher.ContainsAll(1, matcher.GreaterThan(5)),
+			expected: `{"$containsAll": [1, {"$gt": 5}]}`,
+		},
+		{
+			scenario: "contains any",
+			matcher:  matcher.ContainsAny("foo", "bar"),
+			expected: `{"$containsAny": ["foo", "bar"]}`,
+		},
+		{
+			scenario: "min len",
+			matcher:  matcher.MinLen(2),
+			expected: `{"$len": {"$gte": 2}}`,
+		},
+		{
+			scenario: "type",
+			matcher:  matcher.IsType[*time.Time](),
+			expected: `{"$type": "*time.Time"}`,
+		},
+		{
+			scenario: "time",
+			matcher:  matcher.Before(marshalTestTime),
+			expected: `{"$before": "2020-01-02T03:04:05Z"}`,
+		},
+		{
+			scenario: "within duration",
+			matcher:  matcher.WithinDuration(marshalTestTime, 1500*time.Millisecond),
+			expected: `{"$withinDuration": ["2020-01-02T03:04:05Z", "1.5s"]}`,
+		},
+		{
+			scenario: "in delta",
+			matcher:  matcher.InDelta([]float64{1, 2}, 0.1),
+			expected: `{"$inDelta": [[1, 2], 0.1]}`,
+		},
+		{
+			scenario: "elements match",
+			matcher:  matcher.ElementsMatch("a", matcher.Regex("^b")),
+			expected: `{"$elementsMatch": ["a", {"$regex": "^b"}]}`,
+		},
+		{
+			scenario: "each",
+			matcher:  matcher.Each(matcher.GreaterThan(0)),
+			expected: `{"$each": {"$gt": 0}}`,
+		},
+		{
+			scenario: "has key",
+			matcher:  matcher.HasKey("id"),
+			expected: `{"$hasKey": "id"}`,
+		},
+		{
+			scenario: "has entry",
+			matcher:  matcher.HasEntry("id", 1),
+			expected: `{"$hasEntry": ["id", 1]}`,
+		},
+		{
+			scenario: "map containing",
+			matcher:  matcher.MapContaining(map[any]any{"id": 1, "meta": map[any]any{"ok": true}}),
+			expected: `{"$mapContaining": [["id", {"$eq": 1}], ["meta", {"$mapContaining": [["ok", {"$eq": true}]]}]]}`,
+		},
+		{
+			scenario: "has field",
+			matcher:  matcher.HasField("Address.City", matcher.HasPrefix("Ber")),
+			expected: `{"$hasField": ["Address.City", {"$prefix": "Ber"}]}`,
+		},
+		{
+			scenario: "fields",
+			matcher:  matcher.Fields(map[string]any{"Name": "John", "Age": 42}),
+			expected: `{"$fields": {"Name": "John", "Age": 42}}`,
+		},
+		{
+			scenario: "json path",
+			matcher:  matcher.JSONPath("$.created_at", marshalTestTime),
+			expected: `{"$jsonPath": ["$.created_at", "2020-01-02T03:04:05Z"]}`,
+		},
+		{
+			scenario: "json schema",
+			matcher:  matcher.JSONSchema(`{"type": "object"}`),
+			expected: `{"$jsonSchema": {"type": "object"}}`,
+		},
+		{
+			scenario: "json schema - path",
+			matcher:  matcher.JSONSchema("testdata/user.schema.json"),
+			expected: `{"$jsonSchema": "testdata/user.schema.json"}`,
+		},
+		{
+			scenario: "yaml",
+			matcher:  matcher.YAML("id: 1\n"),
+			expected: `{"$yaml": "id: 1\n"}`,
+		},
+		{
+			scenario: "xml",
+			matcher:  matcher.XML("<id>1</id>"),
+			expected: `{"$xml": "<id>1</id>"}`,
+		},
+		{
+			scenario: "callback",
+			matcher:  matcher.Callback(func() matcher.Matcher { return matcher.Len(1) }),
+			expected: `{"$len": 1}`,
+		},
+		{
+			scenario: "named",
+			matcher:  matcher.Named("uuid", nil, matcher.Len(36)),
+			expected: `{"$uuid": null}`,
+		},
+		{
+			scenario: "named - arg",
+			matcher:  matcher.Named("email", map[string]string{"domain": "example.com"}, matcher.HasSuffix("@example.com")),
+			expected: `{"$email": {"domain": "example.com"}}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.scenario, func(t *testing.T) {
+			t.Parallel()
+
+			actual, err := matcher.MarshalJSON(tc.matcher)
+			require.NoError(t, err)
+
+			assert.JSONEq(t, tc.expected, string(actual))
+
+			actual, err = json.Marshal(tc.matcher)
+			require.NoError(t, err)
+
+			assert.JSONEq(t, tc.expected, string(actual))
+		})
+	}
+}
+
+func TestMarshalJSON_Error(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		scenario      string
+		matcher       matcher.Matcher
+		expectedError string
+	}{
+		{
+			scenario:      "func",
+			matcher:       matcher.Func("is odd", nil),
+			expectedError: `matcher does not support json: matcher.funcMatcher is odd`,
+		},
+		{
+			scenario:      "nested func",
+			matcher:       matcher.Not(matcher.Func("is odd", nil)),
+			expectedError: `matcher does not support json: matcher.funcMatcher is odd`,
+		},
+		{
+			scenario:      "json with options",
+			matcher:       matcher.JSON(`{}`, matcher.IgnoreExtraFields()),
+			expectedError: `json matcher with options or embedded matchers is not supported`,
+		},
+		{
+			scenario:      "fields with options",
+			matcher:       matcher.HasField("name", "John", matcher.WithUnexportedFields()),
+			expectedError: `field matcher with options is not supported`,
+		},
+		{
+			scenario:      "nested unsupported value",
+			matcher:       matcher.Each(matcher.Func("is odd", nil)),
+			expectedError: `matcher does not support json: matcher.funcMatcher is odd`,
+		},
+		{
+			scenario:      "unsupported value",
+			matcher:       matcher.Equal(make(chan int)),
+			expectedError: `eq: json: unsupported type: chan int`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.scenario, func(t *testing.T) {
+			t.Parallel()
+
+			actual, err := matcher.MarshalJSON(tc.matcher)
+
+			assert.Nil(t, actual)
+			require.EqualError(t, err, tc.expectedError)
+		})
+	}
+}
+
+func TestParseJSON(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		scenario       string
+		data           string
+		expected       string
+		expectedFormat string
+		matched        []any
+		notMatched     []any
+	}{
+		{
+			scenario:       "value",
+			data:           `"foo"`,
+			expected:       "foo",
+			expectedFormat: "<foo>",
+			matched:        []any{"foo"},
+			notMatched:     []any{"bar"},
+		},
+		{
+			scenario:       "object",
+			data:           `{"id": 1}`,
+			expected:       "map[id:1]",
+			expectedFormat: "<map[id:1]>",
+			matched:        []any{map[string]any{"id": 1.0}},
+		},
+		{
+			scenario:       "number",
+			data:           `3`,
+			expected:       "3",
+			expectedFormat: "<3>",
+			matched:        []any{3, 3.0},
+			notMatched:     []any{"3"},
+		},
+		{
+			scenario:   "equal",
+			data:       `{"$eq": 1.5}`,
+			expected:   "1.5",
+			matched:    []any{1.5, float32(1.5)},
+			notMatched: []any{1},
+		},
+		{
+			scenario:   "equal - integer",
+			data:       `{"$eq": 3}`,
+			expected:   "3",
+			matched:    []any{3, int64(3), uint8(3), 3.0, json.Number("3")},
+			notMatched: []any{"3", 4, nil},
+		},
+		{
+			scenario:   "regex",
+			data:       `{"$regex": "^foo"}`,
+			expected:   "^foo",
+			matched:    []any{"foobar"},
+			notMatched: []any{"bar"},
+		},
+		{
+			scenario:   "prefix",
+			data:       `{"$prefix": "foo"}`,
+			matched:    []any{"foobar"},
+			notMatched: []any{"bar"},
+		},
+		{
+			scenario:   "suffix",
+			data:       `{"$suffix": "bar"}`,
+			matched:    []any{"foobar"},
+			notMatched: []any{"foo"},
+		},
+		{
+			scenario:   "equal fold",
+			data:       `{"$equalFold": "foo"}`,
+			matched:    []any{"FOO"},
+			notMatched: []any{"bar"},
+		},
+		{
+			scenario:   "wildcard",
+			data:       `{"$wildcard": "foo*bar"}`,
+			expected:   "^foo.*bar$",
+			matched:    []any{"foo-bar"},
+			notMatched: []any{"foo-baz"},
+		},
+		{
+			scenario:   "len",
+			data:       `{"$len": 3}`,
+			expected:   "len is 3",
+			matched:    []any{"foo", []int{1, 2, 3}},
+			notMatched: []any{"fo"},
+		},
+		{
+			scenario:   "len matches",
+			data:       `{"$len": {"$gt": 2}}`,
+			expected:   "len is > 2",
+			matched:    []any{"foo"},
+			notMatched: []any{"fo"},
+		},
+		{
+			scenario:   "rune len",
+			data:       `{"$runeLen": 2}`,
+			matched:    []any{"é!"},
+			notMatched: []any{"é"},
+		},
+		{
+			scenario: "any",
+			data:     `{"$any": true}`,
+			matched:  []any{nil, 42},
+		},
+		{
+			scenario:   "empty",
+			data:       `{"$empty": true}`,
+			matched:    []any{""},
+			notMatched: []any{"foo"},
+		},
+		{
+			scenario:   "not empty",
+			data:       `{"$notEmpty": true}`,
+			matched:    []any{"foo"},
+			notMatched: []any{""},
+		},
+		{
+			scenario:   "and",
+			data:       `{"$and": ["foo", {"$len": 3}]}`,
+			expected:   "foo and len is 3",
+			matched:    []any{"foo"},
+			notMatched: []any{"bar"},
+		},
+		{
+			scenario:   "or",
+			data:       `{"$or": [{"$prefix": "a"}, {"$suffix": "z"}]}`,
+			matched:    []any{"ab", "yz"},
+			notMatched: []any{"b"},
+		},
+		{
+			scenario:   "not",
+			data:       `{"$not": {"$empty": true}}`,
+			expected:   "not is empty",
+			matched:    []any{"foo"},
+			notMatched: []any{""},
+		},
+		{
+			scenario:   "compare",
+			data:       `{"$and": [{"$gt": 1}, {"$lte": 3}]}`,
+			matched:    []any{2, 3.0, int64(3)},
+			notMatched: []any{1, 4},
+		},
+		{
+			scenario:   "between",
+			data:       `{"$between": ["b", "d"]}`,
+			matched:    []any{"c"},
+			notMatched: []any{"a"},
+		},
+		{
+			scenario:   "json",
+			data:       `{"$json": {"id": "<ignore-diff>"}}`,
+			matched:    []any{`{"id": 1}`},
+			notMatched: []any{`{"name": 1}`},
+		},
+		{
+			scenario:   "contains",
+			data:       `{"$contains": "oo"}`,
+			expected:   `contains "oo"`,
+			matched:    []any{"foo", []string{"oo"}},
+			notMatched: []any{"bar"},
+		},
+		{
+			scenario:   "contains all",
+			data:       `{"$containsAll": [1, {"$gt": 5}]}`,
+			matched:    []any{[]int{1, 6}},
+			notMatched: []any{[]int{1, 2}},
+		},
+		{
+			scenario:   "contains any",
+			data:       `{"$containsAny": ["a", "z"]}`,
+			matched:    []any{"abc", "xyz"},
+			notMatched: []any{"b"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.scenario, func(t *testing.T) {
+			t.Parallel()
+
+			m, err := matcher.ParseJSON([]byte(tc.data))
+			require.NoError(t, err)
+
+			if tc.expected != "" {
+				assert.Equal(t, tc.expected, m.Expected())
+			}
+
+			if tc.expectedFormat != "" {
+				assert.Equal(t, tc.expectedFormat, fmt.Sprintf("%v", m))
+			}
+
+			for _, v := range tc.matched {
+				result, err := m.Match(v)
+
+				assert.True(t, result, "%#v", v)
+				assert.NoError(t, err)
+			}
+
+			for _, v := range tc.notMatched {
+				result, _ := m.Match(v) //nolint: errcheck
+
+				assert.False(t, result, "%#v", v)
+			}
+		})
+	}
+}
+
+func TestParseJSON_Error(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		scenario      string
+		data          string
+		expectedError string
+	}{
+		{
+			scenario:      "invalid json",
+			data:          `{`,
+			expectedError: `unexpected end of JSON input`,
+		},
+		{
+			scenario:      "unknown",
+			data:          `{"$uuid": null}`,
+			expectedError: `$uuid: unknown matcher`,
+		},
+		{
+			scenario:      "too many keys",
+			data:          `{"$len": 1, "id": 1}`,
+			expectedError: `a matcher must have exactly one key`,
+		},
+		{
+			scenario:      "invalid regex",
+			data:          `{"$regex": "a**"}`,
+			expectedError: "$regex: error parsing regexp: invalid nested repetition operator: `**`",
+		},
+		{
+			scenario:      "invalid string",
+			data:          `{"$prefix": 1}`,
+			expectedError: `$prefix: json: cannot unmarshal number into Go value of type string`,
+		},
+		{
+			scenario:      "invalid length",
+			data:          `{"$len": 1.5}`,
+			expectedError: `$len: length must be a non-negative integer or a matcher`,
+		},
+		{
+			scenario:      "negative length",
+			data:          `{"$len": -1}`,
+			expectedError: `$len: length must be a non-negative integer or a matcher`,
+		},
+		{
+			scenario:      "invalid list",
+			data:          `{"$and": {"$any": true}}`,
+			expectedError: `$and: expected an array of matchers`,
+		},
+		{
+			scenario:      "nested error",
+			data:          `{"$or": ["a", {"$not": {"$foo": 1}}]}`,
+			expectedError: `$or: [1]: $not: $foo: unknown matcher`,
+		},
+		{
+			scenario:      "invalid compare",
+			data:          `{"$gt": true}`,
+			expectedError: `$gt: expected a number or a string`,
+		},
+		{
+			scenario:      "invalid between",
+			data:          `{"$between": [1]}`,
+			expectedError: `$between: expected an array of lower and upper bounds`,
+		},
+		{
+			scenario:      "invalid contains",
+			data:          `{"$containsAll": "a"}`,
+			expectedError: `$containsAll: expected an array of matchers`,
+		},
+		{
+			scenario:      "invalid approx",
+			data:          `{"$inDelta": [1]}`,
+			expectedError: `$inDelta: expected an array of the expectation and the tolerance`,
+		},
+		{
+			scenario:      "invalid approx expectation",
+			data:          `{"$withinULP": ["a", 1]}`,
+			expectedError: `$withinULP: expectation must be a number, or a slice, an array or a map of numbers`,
+		},
+		{
+			scenario:      "invalid time",
+			data:          `{"$after": "yesterday"}`,
+			expectedError: `$after: parsing time "yesterday" as "2006-01-02T15:04:05Z07:00": cannot parse "yesterday" as "2006"`,
+		},
+		{
+			scenario:      "invalid duration",
+			data:          `{"$withinDuration": ["2020-01-02T03:04:05Z", "1x"]}`,
+			expectedError: `$withinDuration: time: unknown unit "x" in duration "1x"`,
+		},
+		{
+			scenario:      "invalid entry",
+			data:          `{"$hasEntry": "id"}`,
+			expectedError: `$hasEntry: expected an array of a key and a value`,
+		},
+		{
+			scenario:      "invalid entries",
+			data:          `{"$mapContaining": [["id"]]}`,
+			expectedError: `$mapContaining: expected an array of key and value pairs`,
+		},
+		{
+			scenario:      "invalid field",
+			data:          `{"$hasField": [1, 2]}`,
+			expectedError: `$hasField: expected an array of a path and a value`,
+		},
+		{
+			scenario:      "invalid json path",
+			data:          `{"$jsonPath": ["id", 1]}`,
+			expectedError: `$jsonPath: invalid json path "id": must start with $`,
+		},
+		{
+			scenario:      "invalid json schema",
+			data:          `{"$jsonSchema": "{"}`,
+			expectedError: `$jsonSchema: invalid json schema: unexpected EOF`,
+		},
+		{
+			scenario:      "invalid yaml",
+			data:          `{"$yaml": 1}`,
+			expectedError: `$yaml: expected a string`,
+		},
+		{
+			scenario:      "invalid xml",
+			data:          `{"$xml": "<a>"}`,
+			expectedError: `$xml: XML syntax error on line 1: unexpected EOF`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.scenario, func(t *testing.T) {
+			t.Parallel()
+
+			m, err := matcher.ParseJSON([]byte(tc.data))
+
+			assert.Nil(t, m)
+			require.EqualError(t, err, tc.expectedError)
+		})
+	}
+}
+
+func TestMarshalJSON_RoundTrip(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		scenario string
+		matcher  matcher.Matcher
+		matched  []any
+	}{
+		{
+			scenario: "logical",
+			matcher: matcher.And(
+				matcher.Not(matcher.IsEmpty()),
+				matcher.Or(matcher.Regex("^a"), matcher.HasSuffix("}")),
+				matcher.LenMatches(matcher.Between(1, 10)),
+				matcher.JSON(`{"id":1}`),
+			),
+			matched: []any{`{"id":1}`},
+		},
+		{
+			scenario: "equal - int",
+			matcher:  matcher.Equal(3),
+			matched:  []any{3},
+		},
+		{
+			scenario: "equal - float",
+			matcher:  matcher.Equal(2.5),
+			matched:  []any{2.5},
+		},
+		{
+			scenario: "contains",
+			matcher:  matcher.ContainsAll("foo", 3, matcher.HasPrefix("b")),
+			matched:  []any{[]any{"bar", 3, "foo"}},
+		},
+		{
+			scenario: "len",
+			matcher:  matcher.And(matcher.MinLen(2), matcher.MaxLen(3)),
+			matched:  []any{"ab", []int{1, 2, 3}},
+		},
+		{
+			scenario: "type",
+			matcher:  matcher.Or(matcher.IsType[int](), matcher.SameTypeAs(&time.Time{})),
+			matched:  []any{1, &time.Time{}},
+		},
+		{
+			scenario: "time",
+			matcher: matcher.And(
+				matcher.After(marshalTestTime.Add(-time.Hour)),
+				matcher.Before(marshalTestTime.Add(time.Hour)),
+				matcher.WithinDuration(marshalTestTime, 1500*time.Millisecond),
+				matcher.TimeBetween(marshalTestTime, marshalTestTime.Add(time.Second)),
+			),
+			matched: []any{marshalTestTime.Add(time.Second), "2020-01-02T03:04:05Z"},
+		},
+		{
+			scenario: "time equal",
+			matcher:  matcher.TimeEqual(marshalTestTime.In(time.FixedZone("ICT", 7*3600))),
+			matched:  []any{marshalTestTime},
+		},
+		{
+			scenario: "approx",
+			matcher: matcher.And(
+				matcher.InDelta([]float64{1, 2}, 0.1),
+				matcher.InEpsilon([]float64{1, 2}, 0.1),
+				matcher.WithinULP([]float64{1, 2}, 2),
+			),
+			matched: []any{[]float64{1, 2}},
+		},
+		{
+			scenario: "collection",
+			matcher: matcher.And(
+				matcher.ElementsMatch("a", 1, matcher.Regex("^b")),
+				matcher.ConsistOf(matcher.Regex("^b"), "a", 1),
+				matcher.Each(matcher.Not(matcher.IsEmpty())),
+				matcher.AnyElement("a"),
+			),
+			matched: []any{[]any{"bcd", "a", 1}},
+		},
+		{
+			scenario: "map",
+			matcher: matcher.And(
+				matcher.HasKey("id"),
+				matcher.HasValue(matcher.Regex("^J")),
+				matcher.HasEntry(matcher.HasPrefix("na"), "John"),
+				matcher.KeysMatch(matcher.Contains("meta")),
+				matcher.MapContaining(map[any]any{"id": 1, "meta": map[any]any{"ok": true}}),
+			),
+			matched: []any{map[string]any{"id": 1, "name": "John", "meta": map[string]any{"ok": true, "tags": 2}}},
+		},
+		{
+			scenario: "fields",
+			matcher: matcher.And(
+				matcher.HasField("Name", "John"),
+				matcher.Fields(map[string]any{"Name": matcher.HasPrefix("J"), "Age": 42}),
+			),
+			matched: []any{struct {
+				Name string
+				Age  int
+			}{Name: "John", Age: 42}},
+		},
+		{
+			scenario: "json path",
+			matcher: matcher.And(
+				matcher.JSONPath("$.id", 42),
+				matcher.JSONPointer("/tags", matcher.ConsistOf("a", "b")),
+			),
+			matched: []any{`{"id": 42, "tags": ["a", "b"]}`},
+		},
+		{
+			scenario: "json schema",
+			matcher: matcher.And(
+				matcher.JSONSchema(`{"type": "object", "required": ["id"]}`),
+				matcher.JSONSchema("testdata/user.schema.json"),
+			),
+			matched: []any{`{"id": 1, "name": "John"}`},
+		},
+		{
+			scenario: "yaml",
+			matcher:  matcher.YAML("id: 1\nname: <ignore-diff>\n"),
+			matched:  []any{"name: John\nid: 1\n"},
+		},
+		{
+			scenario: "xml",
+			matcher:  matcher.XML(`<user id="1"><name>John</name></user>`),
+			matched:  []any{`<user id="1">  <name> John </name></user>`},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.scenario, func(t *testing.T) {
+			t.Parallel()
+
+			data, err := matcher.MarshalJSON(tc.matcher)
+			require.NoError(t, err)
+
+			decoded, err := matcher.ParseJSON(data)
+			require.NoError(t, err)
+
+			assert.Equal(t, tc.matcher.Expected(), decoded.Expected())
+
+			for _, v := range tc.matched {
+				result, err := tc.matcher.Match(v)
+
+				require.True(t, result, "%#v", v)
+				require.NoError(t, err)
+
+				result, err = decoded.Match(v)
+
+				assert.True(t, result, "%#v", v)
+				assert.NoError(t, err)
+			}
+
+			actual, err := matcher.MarshalJSON(decoded)
+			require.NoError(t, err)
+
+			assert.JSONEq(t, string(data), string(actual))
+		})
+	}
+}
+
+func TestMarshalJSON_RoundTrip_Equal(t *testing.T) {
+	t.Parallel()
+
+	type user struct {
+		Name string `json:"name"`
+		Age  int    `json:"age"`
+	}
+
+	testCases := []struct {
+		scenario   string
+		expected   any
+		matched    []any
+		notMatched []any
+	}{
+		{
+			scenario:   "slice",
+			expected:   []int{1, 2},
+			matched:    []any{[]int{1, 2}},
+			notMatched: []any{[]int{2, 1}, []int{1, 2, 3}, "[1,2]"},
+		},
+		{
+			scenario:   "map",
+			expected:   map[string]int{"a": 1},
+			matched:    []any{map[string]int{"a": 1}},
+			notMatched: []any{map[string]int{"a": 2}, map[string]int{"a": 1, "b": 2}},
+		},
+		{
+			scenario:   "struct",
+			expected:   user{Name: "John", Age: 42},
+			matched:    []any{user{Name: "John", Age: 42}},
+			notMatched: []any{user{Name: "John", Age: 43}, nil},
+		},
+		{
+			scenario:   "time",
+			expected:   marshalTestTime,
+			matched:    []any{marshalTestTime},
+			notMatched: []any{marshalTestTime.Add(time.Second)},
+		},
+		{
+			scenario:   "string",
+			expected:   "foo",
+			matched:    []any{"foo"},
+			notMatched: []any{"bar", []byte("foo")},
+		},
+		{
+			scenario:   "nil",
+			expected:   nil,
+			matched:    []any{nil},
+			notMatched: []any{0, ""},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.scenario, func(t *testing.T) {
+			t.Parallel()
+
+			m := matcher.Equal(tc.expected)
+
+			data, err := matcher.MarshalJSON(m)
+			require.NoError(t, err)
+
+			decoded, err := matcher.ParseJSON(data)
+			require.NoError(t, err)
+
+			for _, v := range tc.matched {
+				result, err := m.Match(v)
+
+				require.True(t, result, "%#v", v)
+				require.NoError(t, err)
+
+				result, err = decoded.Match(v)
+
+				assert.True(t, result, "%#v", v)
+				assert.NoError(t, err)
+			}
+
+			for _, v := range tc.notMatched {
+				result, err := decoded.Match(v)
+
+				assert.False(t, result, "%#v", v)
+				assert.NoError(t, err)
+			}
+
+			actual, err := matcher.MarshalJSON(decoded)
+			require.NoError(t, err)
+
+			assert.JSONEq(t, string(data), string(actual))
+		})
+	}
+}
+
+func TestParseJSON_Unknown(t *testing.T) {
+	t.Parallel()
+
+	_, err := matcher.ParseJSON([]byte(`{"$uuid": null}`))
+
+	require.ErrorIs(t, err, matcher.ErrUnknownMatcher)
+}
+
+func TestJSONMatcher(t *testing.T) {
+	t.Parallel()
+
+	type expectation struct {
+		Method string              `json:"method"`
+		Body   matcher.JSONMatcher `json:"body"`
+		Header matcher.JSONMatcher `json:"header"`
+	}
+
+	data := `{"method": "POST", "body": {"$jsonPath": ["$.id", {"$gt": 0}]}, "header": null}`
+
+	var e expectation
+
+	err := json.Unmarshal([]byte(data), &e)
+	require.NoError(t, err)
+
+	assert.Nil(t, e.Header.Matcher)
+	assert.Equal(t, "has json path $.id with value > 0", e.Body.Expected())
+
+	result, err := e.Body.Match(`{"id": 42}`)
+
+	assert.True(t, result)
+	assert.NoError(t, err)
+
+	actual, err := json.Marshal(e)
+	require.NoError(t, err)
+
+	assert.JSONEq(t, data, string(actual))
+}
+
+func TestJSONMatcher_Error(t *testing.T) {
+	t.Parallel()
+
+	var m matcher.JSONMatcher
+
+	err := json.Unmarshal([]byte(`{"$uuid": null}`), &m)
+
+	require.ErrorIs(t, err, matcher.ErrUnknownMatcher)
+	assert.Nil(t, m.Matcher)
+
+	_, err = json.Marshal(matcher.JSONMatcher{Matcher: matcher.Func("is odd", nil)})
+
+	require.ErrorContains(t, err, `matcher does not support json: matcher.funcMatcher is odd`)
+}
@@ -1,22 +1,22 @@
 package matcher
 
 import (
-	"errors"
 	"fmt"
 	"reflect"
 	"regexp"
 	"strings"
 
 	"github.com/stretchr/testify/assert"
-	"github.com/swaggest/assertjson"
 
 	"go.nhat.io/matcher/v3/format"
 )
 
 // Any returns a matcher that matches any value.
-var Any = Func("is anything", func(any) (bool, error) {
-	return true, nil
-})
+var Any Matcher = anyMatcher{}
+
+type integer interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 | ~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64
+}
 
 // Matcher determines if the actual matches the expectation.
 //
@@ -26,7 +26,10 @@ type Matcher interface {
 	Expected() string
 }
 
-var _ Matcher = (*equalMatcher)(nil)
+var (
+	_ Matcher   = (*equalMatcher)(nil)
+	_ Explainer = (*equalMatcher)(nil)
+)
 
 // equalMatcher matches by equal string.
 type equalMatcher struct {
@@ -47,15 +50,24 @@ func (m equalMatcher) Match(actual any) (bool, error) {
 	return assert.ObjectsAreEqual(m.expected, actual), nil
 }
 
+// Explain explains why the actual is not expected.
+func (m equalMatcher) Explain(actual any) *Mismatch {
+	return explain(m, actual, "")
+}
+
 func (m equalMatcher) Format(s fmt.State, r rune) {
 	format.Format(s, r, m.expected)
 }
 
-var _ Matcher = (*jsonMatcher)(nil)
+var (
+	_ Matcher   = (*jsonMatcher)(nil)
+	_ Explainer = (*jsonMatcher)(nil)
+)
 
 // jsonMatcher matches by json with <ignore-diff> support.
 type jsonMatcher struct {
 	expected string
+	config   jsonConfig
 }
 
 // Expected returns the expectation.
@@ -70,14 +82,41 @@ func (m jsonMatcher) Match(actual any) (bool, error) {
 		return false, err
 	}
 
-	return assertjson.FailNotEqual([]byte(m.expected), actualBytes) == nil, nil
+	diff, err := m.config.compare([]byte(m.expected), actualBytes)
+	if err != nil {
+		return false, err
+	}
+
+	return diff == nil, nil
+}
+
+// Explain explains why the actual is not expected.
+func (m jsonMatcher) Explain(actual any) *Mismatch {
+	actualBytes, err := jsonVal(actual)
+	if err != nil {
+		return newMismatch(m, actual, "", err)
+	}
+
+	diff, err := m.config.compare([]byte(m.expected), actualBytes)
+	if err != nil {
+		return newMismatch(m, actual, "", err)
+	}
+
+	if diff != nil {
+		return newMismatch(m, actual, diff.Error(), nil)
+	}
+
+	return nil
 }
 
 func (m jsonMatcher) Format(s fmt.State, r rune) {
 	format.Format(s, r, m.expected)
 }
 
-var _ Matcher = (*regexMatcher)(nil)
+var (
+	_ Matcher   = (*regexMatcher)(nil)
+	_ Explainer = (*regexMatcher)(nil)
+)
 
 // regexMatcher matches by regex.
 type regexMatcher struct {
@@ -98,67 +137,114 @@ func (m regexMatcher) Match(actual any) (bool, error) {
 	return false, nil
 }
 
+// Explain explains why the actual is not expected.
+func (m regexMatcher) Explain(actual any) *Mismatch {
+	if strVal(actual) == nil {
+		return newMismatch(m, actual, fmt.Sprintf("got %T, not a string", actual), nil)
+	}
+
+	return explain(m, actual, "")
+}
+
 func (m regexMatcher) Format(s fmt.State, r rune) {
 	format.Format(s, r, m.regexp)
 }
 
-var _ Matcher = (*typeMatcher)(nil)
+var (
+	_ Matcher   = (*typeMatcher)(nil)
+	_ Explainer = (*typeMatcher)(nil)
+)
 
 // typeMatcher is a .typeMatcher.
 type typeMatcher struct {
 	typeOf reflect.Type
+
+	// name is the name of the type, like "*time.Time". The matchers unmarshaled from JSON do not have the type, and match
+	// by the name.
+	name string
 }
 
 func (m typeMatcher) Match(actual any) (bool, error) {
+	if m.typeOf == nil {
+		return fmt.Sprintf("%T", actual) == m.name, nil
+	}
+
 	return reflect.DeepEqual(m.typeOf, reflect.TypeOf(actual)), nil
 }
 
 func (m typeMatcher) Expected() string {
-	return "type is " + m.typeOf.String()
+	return "type is " + m.name
+}
+
+// Explain explains why the actual is not expected.
+func (m typeMatcher) Explain(actual any) *Mismatch {
+	return explain(m, actual, fmt.Sprintf("got type %T", actual))
 }
 
 func (m typeMatcher) Format(s fmt.State, _ rune) {
-	_, _ = fmt.Fprintf(s, "<type is %s>", m.typeOf.String()) //nolint: errcheck
+	_, _ = fmt.Fprintf(s, "<type is %s>", m.name) //nolint: errcheck
 }
 
-var _ Matcher = (*lenMatcher)(nil)
+var (
+	_ Matcher   = (*lenMatcher)(nil)
+	_ Explainer = (*lenMatcher)(nil)
+)
 
 // lenMatcher matches by the length of the value.
 type lenMatcher struct {
-	expected int
+	matcher Matcher
+	runes   bool
 }
 
 // Match determines if the actual is expected.
-func (m lenMatcher) Match(actual any) (_ bool, err error) {
+func (m lenMatcher) Match(actual any) (bool, error) {
 	if actual == nil {
 		return false, nil
 	}
 
-	defer func() {
-		if r := recover(); r != nil {
-			err = errors.New(recovered(r)) //nolint: err113
-		}
-	}()
+	l, err := lenVal(actual, m.runes)
+	if err != nil {
+		return false, err
+	}
 
-	val := reflect.ValueOf(actual)
+	return m.matcher.Match(l)
+}
 
-	if val.Type().Kind() == reflect.Ptr {
-		val = val.Elem()
+// Explain explains why the actual is not expected.
+func (m lenMatcher) Explain(actual any) *Mismatch {
+	if actual == nil {
+		return newMismatch(m, actual, "", nil)
 	}
 
-	return val.Len() == m.expected, nil
+	l, err := lenVal(actual, m.runes)
+	if err != nil {
+		return newMismatch(m, actual, "", err)
+	}
+
+	if c := Explain(m.matcher, l); c != nil {
+		return newMismatch(m, actual, fmt.Sprintf("got len %d", l), c.Err)
+	}
+
+	return nil
 }
 
 // Expected returns the expectation.
 func (m lenMatcher) Expected() string {
-	return fmt.Sprintf("len is %d", m.expected)
+	if m.runes {
+		return "rune len is " + m.matcher.Expected()
+	}
+
+	return "len is " + m.matcher.Expected()
 }
 
 func (m lenMatcher) Format(s fmt.State, _ rune) {
-	_, _ = fmt.Fprintf(s, "<len is %d>", m.expected) //nolint: errcheck
+	_, _ = fmt.Fprintf(s, "<%s>", m.Expected()) //nolint: errcheck
 }
 
-var _ Matcher = (*emptyMatcher)(nil)
+var (
+	_ Matcher   = (*emptyMatcher)(nil)
+	_ Explainer = (*emptyMatcher)(nil)
+)
 
 // emptyMatcher checks whether the value is empty.
 type emptyMatcher struct{}
@@ -168,6 +254,11 @@ func (emptyMatcher) Match(actual any) (bool, error) {
 	return isEmpty(actual), nil
 }
 
+// Explain explains why the actual is not expected.
+func (m emptyMatcher) Explain(actual any) *Mismatch {
+	return explain(m, actual, "")
+}
+
 // Expected returns the expectation.
 func (emptyMatcher) Expected() string {
 	return "is empty"
@@ -177,7 +268,10 @@ func (emptyMatcher) Format(s fmt.State, _ rune) {
 	_, _ = s.Write([]byte("<is empty>")) //nolint: errcheck
 }
 
-var _ Matcher = (*notEmptyMatcher)(nil)
+var (
+	_ Matcher   = (*notEmptyMatcher)(nil)
+	_ Explainer = (*notEmptyMatcher)(nil)
+)
 
 // notEmptyMatcher checks whether the value is not empty.
 type notEmptyMatcher struct{}
@@ -187,6 +281,11 @@ func (notEmptyMatcher) Match(actual any) (bool, error) {
 	return !isEmpty(actual), nil
 }
 
+// Explain explains why the actual is not expected.
+func (m notEmptyMatcher) Explain(actual any) *Mismatch {
+	return explain(m, actual, "")
+}
+
 // Expected returns the expectation.
 func (notEmptyMatcher) Expected() string {
 	return "is not empty"
@@ -196,7 +295,37 @@ func (notEmptyMatcher) Format(s fmt.State, _ rune) {
 	_, _ = s.Write([]byte("<is not empty>")) //nolint: errcheck
 }
 
-var _ Matcher = (*funcMatcher)(nil)
+var (
+	_ Matcher   = (*anyMatcher)(nil)
+	_ Explainer = (*anyMatcher)(nil)
+)
+
+// anyMatcher matches any value.
+type anyMatcher struct{}
+
+// Match determines if the actual is expected.
+func (anyMatcher) Match(any) (bool, error) {
+	return true, nil
+}
+
+// Explain explains why the actual is not expected.
+func (anyMatcher) Explain(any) *Mismatch {
+	return nil
+}
+
+// Expected returns the expectation.
+func (anyMatcher) Expected() string {
+	return "is anything"
+}
+
+func (m anyMatcher) Format(s fmt.State, _ rune) {
+	_, _ = fmt.Fprintf(s, "<%s>", m.Expected()) //nolint: errcheck
+}
+
+var (
+	_ Matcher   = (*funcMatcher)(nil)
+	_ Explainer = (*funcMatcher)(nil)
+)
 
 // funcMatcher checks by calling a function.
 type funcMatcher struct {
@@ -209,6 +338,11 @@ func (f funcMatcher) Match(actual any) (bool, error) {
 	return f.match(actual)
 }
 
+// Explain explains why the actual is not expected.
+func (f funcMatcher) Explain(actual any) *Mismatch {
+	return explain(f, actual, "")
+}
+
 // Expected returns the expectation.
 func (f funcMatcher) Expected() string {
 	return f.expected
@@ -218,7 +352,10 @@ func (f funcMatcher) Format(s fmt.State, _ rune) {
 	_, _ = fmt.Fprintf(s, "<%s>", f.expected) //nolint: errcheck
 }
 
-var _ Matcher = (*Callback)(nil)
+var (
+	_ Matcher   = (*Callback)(nil)
+	_ Explainer = (*Callback)(nil)
+)
 
 // Callback matches by calling a function.
 type Callback func() Matcher
@@ -233,6 +370,11 @@ func (m Callback) Match(actual any) (bool, error) {
 	return m().Match(actual)
 }
 
+// Explain explains why the actual is not expected.
+func (m Callback) Explain(actual any) *Mismatch {
+	return Explain(m(), actual)
+}
+
 // Matcher returns the matcher.
 func (m Callback) Matcher() Matcher {
 	return m()
@@ -249,13 +391,35 @@ func Equalf(expected string, args ...any) Matcher {
 }
 
 // JSON matches two json strings with <ignore-diff> support.
-func JSON(expected any) Matcher {
-	ex, err := jsonVal(expected)
+//
+// If the expectation is not a string or a []byte, it could contain matchers, like map[string]any{"id": Regex("^[0-9]+$")}.
+// They are evaluated at the corresponding nodes of the actual, which are decoded by encoding/json, so numbers are
+// float64, objects are map[string]any and arrays are []any. In the diff, a matcher is shown as "<expectation>", which
+// is only a display: the strings that look like it are compared as they are.
+//
+// The options relax the comparison, see IgnoreArrayOrder, IgnoreExtraFields, IgnorePaths and NumberTolerance.
+func JSON(expected any, opts ...JSONOption) Matcher {
+	c := jsonOptions(opts)
+
+	embedded, ok, err := embedJSONMatchers(expected)
+	if err != nil {
+		panic(err)
+	}
+
+	var ex []byte
+
+	if ok {
+		c.embedded = embedded
+		ex, err = marshalJSONPlaceholders(jsonPlaceholders(embedded))
+	} else {
+		ex, err = jsonVal(expected)
+	}
+
 	if err != nil {
 		panic(err)
 	}
 
-	return jsonMatcher{expected: string(ex)}
+	return jsonMatcher{expected: string(ex), config: c}
 }
 
 // Regex matches two strings by using regex.
@@ -289,17 +453,63 @@ func Wildcard[T ~string](pattern T) Matcher {
 func IsType[T any]() Matcher {
 	var t *T
 
-	return typeMatcher{typeOf: reflect.TypeOf(t).Elem()}
+	return newTypeMatcher(reflect.TypeOf(t).Elem())
 }
 
 // SameTypeAs matches two types.
 func SameTypeAs(expected any) Matcher {
-	return typeMatcher{typeOf: reflect.TypeOf(expected)}
+	return newTypeMatcher(reflect.TypeOf(expected))
+}
+
+func newTypeMatcher(t reflect.Type) typeMatcher {
+	return typeMatcher{typeOf: t, name: fmt.Sprintf("%v", t)}
 }
 
 // Len matches by the length of the value.
-func Len[T ~int | ~int8 | ~int16 | ~int32 | ~int64 | ~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64](expected T) Matcher {
-	return lenMatcher{expected: int(expected)}
+//
+// The value could be an array, a chan, a map, a slice, a string, or a pointer to them. Otherwise, a *LenError is
+// returned.
+func Len[T integer](expected T) Matcher {
+	return lenMatcher{matcher: Equal(int(expected))}
+}
+
+// LenMatches matches if the length of the value matches the expectation, like LenMatches(GreaterThan(2)). The
+// expectation is converted using Match().
+//
+// See Len for the supported values.
+func LenMatches(expected any) Matcher {
+	return lenMatcher{matcher: makeNestedMatcher(expected)}
+}
+
+// MinLen matches if the length of the value is at least the expectation.
+//
+// See Len for the supported values.
+func MinLen[T integer](expected T) Matcher {
+	return lenMatcher{matcher: GreaterOrEqual(int(expected))}
+}
+
+// MaxLen matches if the length of the value is at most the expectation.
+//
+// See Len for the supported values.
+func MaxLen[T integer](expected T) Matcher {
+	return lenMatcher{matcher: LessOrEqual(int(expected))}
+}
+
+// LenBetween matches if the length of the value is between lower and upper, inclusively.
+//
+// See Len for the supported values.
+func LenBetween[T integer](lower, upper T) Matcher {
+	return lenMatcher{matcher: Between(int(lower), int(upper))}
+}
+
+// RuneLen is like Len, but strings and []byte are measured by the number of runes instead of bytes.
+func RuneLen[T integer](expected T) Matcher {
+	return lenMatcher{matcher: Equal(int(expected)), runes: true}
+}
+
+// RuneLenMatches is like LenMatches, but strings and []byte are measured by the number of runes instead of bytes.
+func RuneLenMatches(expected any) Matcher {
+	return lenMatcher{matcher: makeNestedMatcher(expected), runes: true}
 }
 
 // IsEmpty checks whether the value is empty.
@@ -385,6 +595,26 @@ func (m *orLogicalMatcher) Match(actual any) (bool, error) {
 	return false, nil
 }
 
+// Explain explains why none of the matchers match.
+func (m *orLogicalMatcher) Explain(actual any) *Mismatch {
+	children := make([]*Mismatch, 0, len(m.matchers))
+
+	for _, matcher := range m.matchers {
+		c := Explain(matcher, actual)
+		if c == nil {
+			return nil
+		}
+
+		children = append(children, c)
+
+		if c.Err != nil {
+			break
+		}
+	}
+
+	return &Mismatch{Expected: m.Expected(), Actual: actual, Children: children}
+}
+
 // Or returns a matcher that matches if any of the matchers match.
 func Or(matchers ...any) Matcher {
 	return &orLogicalMatcher{
@@ -411,6 +641,30 @@ func (m *andLogicalMatcher) Match(actual any) (bool, error) {
 	return true, nil
 }
 
+// Explain explains which of the matchers do not match.
+func (m *andLogicalMatcher) Explain(actual any) *Mismatch {
+	var children []*Mismatch
+
+	for _, matcher := range m.matchers {
+		c := Explain(matcher, actual)
+		if c == nil {
+			continue
+		}
+
+		children = append(children, c)
+
+		if c.Err != nil {
+			break
+		}
+	}
+
+	if len(children) == 0 {
+		return nil
+	}
+
+	return &Mismatch{Expected: m.Expected(), Actual: actual, Children: children}
+}
+
 // And returns a matcher that matches if all of the matchers match.
 func And(matchers ...any) Matcher {
 	return &andLogicalMatcher{
@@ -421,6 +675,47 @@ func And(matchers ...any) Matcher {
 	}
 }
 
+var (
+	_ Matcher   = (*notLogicalMatcher)(nil)
+	_ Explainer = (*notLogicalMatcher)(nil)
+)
+
+// notLogicalMatcher negates the result of a matcher.
+type notLogicalMatcher struct {
+	matcher Matcher
+}
+
+// Match determines if the actual is expected.
+func (m notLogicalMatcher) Match(actual any) (bool, error) {
+	ok, err := m.matcher.Match(actual)
+	if err != nil {
+		return false, err
+	}
+
+	return !ok, nil
+}
+
+// Expected returns the expectation.
+func (m notLogicalMatcher) Expected() string {
+	return "not " + m.matcher.Expected()
+}
+
+// Explain explains why the actual is not expected.
+func (m notLogicalMatcher) Explain(actual any) *Mismatch {
+	return explain(m, actual, "")
+}
+
+func (m notLogicalMatcher) Format(s fmt.State, _ rune) {
+	_, _ = fmt.Fprintf(s, "<%s>", m.Expected()) //nolint: errcheck
+}
+
+// Not returns a matcher that matches if the matcher does not match.
+//
+// If the matcher returns an error, the error is returned as is and the result is not negated.
+func Not(matcher any) Matcher {
+	return notLogicalMatcher{matcher: makeNestedMatcher(matcher)}
+}
+
 func makeNestableMatchers(v ...any) []Matcher {
 	matchers := make([]Matcher, len(v))
 
@@ -2,6 +2,7 @@ package matcher_test
 
 import (
 	"fmt"
+	"reflect"
 	"regexp"
 	"testing"
 	"time"
@@ -809,7 +810,7 @@ func TestLen_Match_Error(t *testing.T) {
 	m := matcher.Len(3)
 	actual, err := m.Match(42)
 
-	expected := `reflect: call of reflect.Value.Len on int Value`
+	expected := `len: value of type int does not have a length`
 
 	assert.False(t, actual)
 	require.Error(t, err)
@@ -836,6 +837,135 @@ func TestLen_Expected(t *testing.T) {
 	assert.Equal(t, expected, m.Expected())
 }
 
+func TestLen_Match_LenError(t *testing.T) {
+	t.Parallel()
+
+	_, err := matcher.Len(3).Match(42)
+
+	var lenErr *matcher.LenError
+
+	require.ErrorAs(t, err, &lenErr)
+	assert.Equal(t, reflect.TypeOf(42), lenErr.Type)
+}
+
+func TestLenVariants_Match(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		scenario         string
+		matcher          matcher.Matcher
+		value            any
+		expected         bool
+		expectedExpected string
+	}{
+		{
+			scenario:         "len matches mismatched",
+			matcher:          matcher.LenMatches(matcher.GreaterThan(2)),
+			value:            []int{1, 2},
+			expectedExpected: "len is > 2",
+		},
+		{
+			scenario:         "len matches matched",
+			matcher:          matcher.LenMatches(matcher.GreaterThan(2)),
+			value:            []int{1, 2, 3},
+			expected:         true,
+			expectedExpected: "len is > 2",
+		},
+		{
+			scenario:         "rune len matches mismatched",
+			matcher:          matcher.RuneLenMatches(matcher.GreaterThan(2)),
+			value:            "éé",
+			expectedExpected: "rune len is > 2",
+		},
+		{
+			scenario:         "rune len matches matched",
+			matcher:          matcher.RuneLenMatches(matcher.GreaterThan(2)),
+			value:            "ééé",
+			expected:         true,
+			expectedExpected: "rune len is > 2",
+		},
+		{
+			scenario:         "min len mismatched",
+			matcher:          matcher.MinLen(3),
+			value:            "fo",
+			expectedExpected: "len is >= 3",
+		},
+		{
+			scenario:         "min len matched",
+			matcher:          matcher.MinLen(3),
+			value:            "foo",
+			expected:         true,
+			expectedExpected: "len is >= 3",
+		},
+		{
+			scenario:         "max len mismatched",
+			matcher:          matcher.MaxLen(uint8(1)),
+			value:            map[string]int{"a": 1, "b": 2},
+			expectedExpected: "len is <= 1",
+		},
+		{
+			scenario:         "max len matched",
+			matcher:          matcher.MaxLen(uint8(1)),
+			value:            map[string]int{"a": 1},
+			expected:         true,
+			expectedExpected: "len is <= 1",
+		},
+		{
+			scenario:         "len between mismatched",
+			matcher:          matcher.LenBetween(1, 2),
+			value:            [3]int{},
+			expectedExpected: "len is between 1 and 2",
+		},
+		{
+			scenario:         "len between matched",
+			matcher:          matcher.LenBetween(1, 3),
+			value:            [3]int{},
+			expected:         true,
+			expectedExpected: "len is between 1 and 3",
+		},
+		{
+			scenario:         "rune len of string",
+			matcher:          matcher.RuneLen(3),
+			value:            "héé",
+			expected:         true,
+			expectedExpected: "rune len is 3",
+		},
+		{
+			scenario:         "rune len of bytes",
+			matcher:          matcher.RuneLen(3),
+			value:            []byte("héé"),
+			expected:         true,
+			expectedExpected: "rune len is 3",
+		},
+		{
+			scenario:         "byte len of multibyte string",
+			matcher:          matcher.Len(3),
+			value:            "héé",
+			expectedExpected: "len is 3",
+		},
+		{
+			scenario:         "rune len of slice",
+			matcher:          matcher.RuneLen(2),
+			value:            []int{1, 2},
+			expected:         true,
+			expectedExpected: "rune len is 2",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.scenario, func(t *testing.T) {
+			t.Parallel()
+
+			actual, err := tc.matcher.Match(tc.value)
+
+			assert.Equal(t, tc.expected, actual)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedExpected, tc.matcher.Expected())
+			assert.Equal(t, "<"+tc.expectedExpected+">", fmt.Sprintf("%v", tc.matcher))
+		})
+	}
+}
+
 func TestEmptyMatcher_Format(t *testing.T) {
 	t.Parallel()
 
@@ -1136,3 +1266,118 @@ func TestLogical_Expected(t *testing.T) {
 
 	assert.Equal(t, expected2, actual2.Expected()) //nolint: testifylint
 }
+
+func TestNot_Match(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		scenario string
+		matcher  matcher.Matcher
+		actual   any
+		expected bool
+	}{
+		{
+			scenario: "equal matched",
+			matcher:  matcher.Not("foo"),
+			actual:   "foo",
+		},
+		{
+			scenario: "equal not matched",
+			matcher:  matcher.Not("foo"),
+			actual:   "bar",
+			expected: true,
+		},
+		{
+			scenario: "regex matched",
+			matcher:  matcher.Not(regexp.MustCompile("^foo")),
+			actual:   "foobar",
+		},
+		{
+			scenario: "regex not matched",
+			matcher:  matcher.Not(regexp.MustCompile("^foo")),
+			actual:   "bar",
+			expected: true,
+		},
+		{
+			scenario: "double negation",
+			matcher:  matcher.Not(matcher.Not("foo")),
+			actual:   "foo",
+			expected: true,
+		},
+		{
+			scenario: "logical",
+			matcher:  matcher.And(matcher.Len(3), matcher.Not(matcher.Or("foo", "bar"))),
+			actual:   "baz",
+			expected: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.scenario, func(t *testing.T) {
+			t.Parallel()
+
+			result, err := tc.matcher.Match(tc.actual)
+
+			assert.Equal(t, tc.expected, result)
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestNot_Match_Error(t *testing.T) {
+	t.Parallel()
+
+	m := matcher.Not(matcher.Len(3))
+	result, err := m.Match(42)
+
+	assert.False(t, result)
+	require.EqualError(t, err, `len: value of type int does not have a length`)
+}
+
+func TestNot_Expected(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		scenario string
+		matcher  matcher.Matcher
+		expected string
+	}{
+		{
+			scenario: "simple",
+			matcher:  matcher.Not("foo"),
+			expected: "not foo",
+		},
+		{
+			scenario: "or",
+			matcher:  matcher.Not(matcher.Or("foo", "bar")),
+			expected: "not (foo or bar)",
+		},
+		{
+			scenario: "and",
+			matcher:  matcher.Not(matcher.And(matcher.Regex("^foo"), matcher.Len(5))),
+			expected: "not (^foo and len is 5)",
+		},
+		{
+			scenario: "inside and",
+			matcher:  matcher.And(matcher.Len(3), matcher.Not(matcher.Or("foo", "bar"))),
+			expected: "len is 3 and not (foo or bar)",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.scenario, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tc.expected, tc.matcher.Expected())
+		})
+	}
+}
+
+func TestNotMatcher_Format(t *testing.T) {
+	t.Parallel()
+
+	actual := fmt.Sprintf("%#v", matcher.Not(matcher.Or("foo", "bar")))
+	expected := "<not (foo or bar)>"
+
+	assert.Equal(t, expected, actual)
+}
@@ -0,0 +1,254 @@
+package matcher
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// Factory creates a matcher from the arguments.
+type Factory func(args ...any) (Matcher, error)
+
+// JSONDecoder decodes a custom matcher from the argument of its name in JSON.
+type JSONDecoder func(arg json.RawMessage) (Matcher, error)
+
+// Registry keeps the named matcher factories, like "uuid" or "email", so the matchers could be created by name at
+// runtime, for example by the spec parser, and unmarshaled from JSON. The zero value is ready to use.
+type Registry struct {
+	mu        sync.RWMutex
+	factories map[string]Factory
+	decoders  map[string]JSONDecoder
+}
+
+// Register registers a factory. It is an error if the name is already registered, or is a built-in matcher, like
+// "regex". The built-in names are the operators of MarshalJSON(), which are also the names of the spec package, so a
+// registered name is never shadowed.
+func (r *Registry) Register(name string, f Factory) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := r.checkName(name); err != nil {
+		return err
+	}
+
+	if r.factories == nil {
+		r.factories = make(map[string]Factory)
+	}
+
+	r.factories[name] = f
+
+	return nil
+}
+
+// RegisterDecoder registers a custom matcher that is only unmarshaled from JSON, with its argument as is. The decoded
+// matcher is marshaled back as {"$<name>": <arg>}, see Named(). Unlike a factory, it could not be created by New(), or
+// used by the spec package. It is an error if the name is already registered, or is a built-in matcher.
+func (r *Registry) RegisterDecoder(name string, decode JSONDecoder) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := r.checkName(name); err != nil {
+		return err
+	}
+
+	if r.decoders == nil {
+		r.decoders = make(map[string]JSONDecoder)
+	}
+
+	r.decoders[name] = decode
+
+	return nil
+}
+
+// checkName checks that the name is neither a built-in matcher nor registered. The lock must be held.
+func (r *Registry) checkName(name string) error {
+	_, builtin := jsonDecoders[name]
+	_, factory := r.factories[name]
+	_, decoder := r.decoders[name]
+
+	if builtin || factory || decoder {
+		return fmt.Errorf("%w: %q", ErrDuplicateMatcher, name)
+	}
+
+	return nil
+}
+
+// MustRegister is like Register, but panics if the name is already registered.
+func (r *Registry) MustRegister(name string, f Factory) {
+	if err := r.Register(name, f); err != nil {
+		panic(err)
+	}
+}
+
+// Lookup returns the factory of a name. It is an error if the name is not registered as a factory.
+func (r *Registry) Lookup(name string) (Factory, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	f, ok := r.factories[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownMatcher, name)
+	}
+
+	return f, nil
+}
+
+// Has determines if a name is registered as a factory.
+func (r *Registry) Has(name string) bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	_, ok := r.factories[name]
+
+	return ok
+}
+
+// Names returns the names of the registered factories, sorted.
+func (r *Registry) Names() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	names := make([]string, 0, len(r.factories))
+
+	for name := range r.factories {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
+// New creates a matcher by name. The matcher is marshaled to JSON as {"$<name>": null}, or {"$<name>": [<args>]} if
+// there are arguments, and is unmarshaled by Unmarshal().
+func (r *Registry) New(name string, args ...any) (Matcher, error) {
+	f, err := r.Lookup(name)
+	if err != nil {
+		return nil, err
+	}
+
+	m, err := newNamed(name, f, args)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", name, err)
+	}
+
+	return m, nil
+}
+
+// MustNew is like New, but panics if the name is not registered or the factory fails.
+func (r *Registry) MustNew(name string, args ...any) Matcher {
+	m, err := r.New(name, args...)
+	if err != nil {
+		panic(err)
+	}
+
+	return m
+}
+
+// Unmarshal unmarshals a matcher marshaled by MarshalJSON, with the built-in and the registered matchers. The
+// matchers of the factories are {"$<name>": null}, or {"$<name>": [<args>]}, like the ones created by New(). Any other
+// argument is the only argument of the factory, like {"$uuid": "v4"} marshaled by Named("uuid", "v4", m). The arguments
+// are decoded by encoding/json, so numbers are float64. The decoders get the argument as is. See ParseJSON() for the
+// built-in matchers.
+func (r *Registry) Unmarshal(data []byte) (Matcher, error) {
+	if !isJSONObject(data) {
+		return decodeJSONEqual(data)
+	}
+
+	var obj map[string]json.RawMessage
+
+	if err := json.Unmarshal(data, &obj); err != nil {
+		return nil, err
+	}
+
+	name, arg, ok, err := jsonOperator(obj)
+	if err != nil {
+		return nil, err
+	}
+
+	if !ok {
+		return decodeJSONEqual(data)
+	}
+
+	m, err := r.decode(name, arg)
+	if err != nil {
+		return nil, fmt.Errorf("%s%s: %w", jsonOperatorPrefix, name, err)
+	}
+
+	return m, nil
+}
+
+// decode creates a built-in or a registered matcher from its argument in JSON.
+func (r *Registry) decode(name string, arg json.RawMessage) (Matcher, error) {
+	if decode, ok := jsonDecoders[name]; ok {
+		return decode(r.Unmarshal, arg)
+	}
+
+	r.mu.RLock()
+	_, factory := r.factories[name]
+	decode, decoder := r.decoders[name]
+	r.mu.RUnlock()
+
+	switch {
+	case factory:
+		return r.decodeJSON(name, arg)
+
+	case !decoder:
+		return nil, ErrUnknownMatcher
+	}
+
+	m, err := decode(arg)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, ok := m.(namedMatcher); ok {
+		return m, nil
+	}
+
+	return Named(name, arg, m), nil
+}
+
+// decodeJSON creates a registered matcher from the arguments in JSON.
+func (r *Registry) decodeJSON(name string, arg json.RawMessage) (Matcher, error) {
+	var v any
+
+	if err := json.Unmarshal(arg, &v); err != nil {
+		return nil, err
+	}
+
+	f, err := r.Lookup(name)
+	if err != nil {
+		return nil, err
+	}
+
+	args, ok := v.([]any)
+	if ok || v == nil {
+		return newNamed(name, f, args)
+	}
+
+	// The only argument is kept as is, so that the matcher is marshaled back the same way.
+	m, err := f(v)
+	if err != nil {
+		return nil, err
+	}
+
+	return Named(name, v, m), nil
+}
+
+// newNamed creates a matcher by the factory, and names it so that it is marshaled as {"$<name>": [<args>]}.
+func newNamed(name string, f Factory, args []any) (Matcher, error) {
+	m, err := f(args...)
+	if err != nil {
+		return nil, err
+	}
+
+	var arg any
+
+	if len(args) > 0 {
+		arg = args
+	}
+
+	return Named(name, arg, m), nil
+}
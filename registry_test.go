@@ -0,0 +1,357 @@
+package matcher_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.nhat.io/matcher/v3"
+)
+
+func newTestRegistry(t *testing.T) *matcher.Registry {
+	t.Helper()
+
+	var r matcher.Registry
+
+	r.MustRegister("uuid", func(...any) (matcher.Matcher, error) {
+		return matcher.Regex("^[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}$"), nil
+	})
+
+	r.MustRegister("email", func(args ...any) (matcher.Matcher, error) {
+		switch len(args) {
+		case 0:
+			return matcher.Regex(`^[^@\s]+@[^@\s]+$`), nil
+
+		case 1:
+			return matcher.HasSuffix(fmt.Sprintf("@%s", args[0])), nil
+		}
+
+		return nil, errors.New("too many arguments")
+	})
+
+	return &r
+}
+
+func TestRegistry_New(t *testing.T) {
+	t.Parallel()
+
+	r := newTestRegistry(t)
+
+	testCases := []struct {
+		scenario        string
+		name            string
+		args            []any
+		matched         []any
+		notMatched      []any
+		expectedJSON    string
+		expectedFormat  string
+		expectedExplain string
+	}{
+		{
+			scenario:        "without arguments",
+			name:            "uuid",
+			matched:         []any{"3f2504e0-4f89-11d3-9a0c-0305e82c3301"},
+			notMatched:      []any{"3f2504e0", 42},
+			expectedJSON:    `{"$uuid": null}`,
+			expectedFormat:  `<^[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}$>`,
+			expectedExplain: `expected ^[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}$, got string("3f2504e0")`,
+		},
+		{
+			scenario:        "with arguments",
+			name:            "email",
+			args:            []any{"example.com"},
+			matched:         []any{"john@example.com"},
+			notMatched:      []any{"john@example.org"},
+			expectedJSON:    `{"$email": ["example.com"]}`,
+			expectedFormat:  `<has suffix "@example.com">`,
+			expectedExplain: `expected has suffix "@example.com", got string("john@example.org")`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.scenario, func(t *testing.T) {
+			t.Parallel()
+
+			m, err := r.New(tc.name, tc.args...)
+			require.NoError(t, err)
+
+			for _, v := range tc.matched {
+				result, err := m.Match(v)
+
+				assert.True(t, result, "%#v", v)
+				assert.NoError(t, err)
+			}
+
+			for _, v := range tc.notMatched {
+				result, _ := m.Match(v) //nolint: errcheck
+
+				assert.False(t, result, "%#v", v)
+			}
+
+			data, err := matcher.MarshalJSON(m)
+			require.NoError(t, err)
+
+			assert.JSONEq(t, tc.expectedJSON, string(data))
+			assert.Equal(t, tc.expectedFormat, fmt.Sprintf("%v", m))
+
+			assert.Nil(t, matcher.Explain(m, tc.matched[0]))
+
+			actual := matcher.Explain(m, tc.notMatched[0])
+
+			require.NotNil(t, actual)
+			assert.Equal(t, tc.expectedExplain, actual.String())
+		})
+	}
+}
+
+func TestRegistry_New_Coercion(t *testing.T) {
+	t.Parallel()
+
+	r := newTestRegistry(t)
+
+	m := matcher.Match(r.MustNew("uuid"))
+
+	result, err := m.Match("3f2504e0-4f89-11d3-9a0c-0305e82c3301")
+
+	assert.True(t, result)
+	assert.NoError(t, err)
+
+	m = matcher.And(r.MustNew("email"), matcher.Not(r.MustNew("email", "example.com")))
+
+	result, err = m.Match("john@example.org")
+
+	assert.True(t, result)
+	assert.NoError(t, err)
+}
+
+func TestRegistry_New_Error(t *testing.T) {
+	t.Parallel()
+
+	r := newTestRegistry(t)
+
+	m, err := r.New("iso8601")
+
+	assert.Nil(t, m)
+	require.ErrorIs(t, err, matcher.ErrUnknownMatcher)
+	require.EqualError(t, err, `unknown matcher: "iso8601"`)
+
+	m, err = r.New("email", "a", "b")
+
+	assert.Nil(t, m)
+	require.EqualError(t, err, `email: too many arguments`)
+
+	assert.PanicsWithError(t, `unknown matcher: "iso8601"`, func() {
+		r.MustNew("iso8601")
+	})
+}
+
+func TestRegistry_Register_Duplicate(t *testing.T) {
+	t.Parallel()
+
+	r := newTestRegistry(t)
+
+	err := r.Register("uuid", func(...any) (matcher.Matcher, error) { return matcher.Any, nil })
+
+	require.ErrorIs(t, err, matcher.ErrDuplicateMatcher)
+	require.EqualError(t, err, `matcher is already registered: "uuid"`)
+
+	assert.PanicsWithError(t, `matcher is already registered: "email"`, func() {
+		r.MustRegister("email", func(...any) (matcher.Matcher, error) { return matcher.Any, nil })
+	})
+
+	for _, name := range []string{"regex", "notEmpty", "runeLen", "containsAll", "between"} {
+		err = r.Register(name, func(...any) (matcher.Matcher, error) { return matcher.Any, nil })
+
+		require.ErrorIs(t, err, matcher.ErrDuplicateMatcher)
+		require.EqualError(t, err, fmt.Sprintf("matcher is already registered: %q", name))
+	}
+}
+
+func TestRegistry_RegisterDecoder(t *testing.T) {
+	t.Parallel()
+
+	var r matcher.Registry
+
+	err := r.RegisterDecoder("uuid", func(json.RawMessage) (matcher.Matcher, error) {
+		return matcher.Regex("^[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}$"), nil
+	})
+	require.NoError(t, err)
+
+	err = r.RegisterDecoder("domain", func(arg json.RawMessage) (matcher.Matcher, error) {
+		var domain string
+
+		if err := json.Unmarshal(arg, &domain); err != nil {
+			return nil, err
+		}
+
+		return matcher.HasSuffix("@" + domain), nil
+	})
+	require.NoError(t, err)
+
+	m, err := r.Unmarshal([]byte(`{"$and": [{"$uuid": null}, {"$not": {"$domain": "example.com"}}]}`))
+	require.NoError(t, err)
+
+	result, err := m.Match("3f2504e0-4f89-11d3-9a0c-0305e82c3301")
+
+	assert.True(t, result)
+	assert.NoError(t, err)
+
+	data, err := matcher.MarshalJSON(m)
+	require.NoError(t, err)
+
+	assert.JSONEq(t, `{"$and": [{"$uuid": null}, {"$not": {"$domain": "example.com"}}]}`, string(data))
+
+	_, err = r.Unmarshal([]byte(`{"$domain": 1}`))
+	require.EqualError(t, err, `$domain: json: cannot unmarshal number into Go value of type string`)
+}
+
+func TestRegistry_RegisterDecoder_Duplicate(t *testing.T) {
+	t.Parallel()
+
+	var r matcher.Registry
+
+	decode := func(json.RawMessage) (matcher.Matcher, error) { return matcher.Any, nil }
+
+	require.NoError(t, r.RegisterDecoder("uuid", decode))
+
+	err := r.RegisterDecoder("uuid", decode)
+
+	require.ErrorIs(t, err, matcher.ErrDuplicateMatcher)
+	require.EqualError(t, err, `matcher is already registered: "uuid"`)
+
+	err = r.RegisterDecoder("regex", decode)
+
+	require.ErrorIs(t, err, matcher.ErrDuplicateMatcher)
+	require.EqualError(t, err, `matcher is already registered: "regex"`)
+
+	err = r.Register("uuid", func(...any) (matcher.Matcher, error) { return matcher.Any, nil })
+
+	require.ErrorIs(t, err, matcher.ErrDuplicateMatcher)
+
+	r.MustRegister("email", func(...any) (matcher.Matcher, error) { return matcher.Any, nil })
+
+	err = r.RegisterDecoder("email", decode)
+
+	require.ErrorIs(t, err, matcher.ErrDuplicateMatcher)
+
+	assert.False(t, r.Has("uuid"))
+	assert.Equal(t, []string{"email"}, r.Names())
+}
+
+func TestRegistry_Unmarshal(t *testing.T) {
+	t.Parallel()
+
+	r := newTestRegistry(t)
+
+	m, err := r.Unmarshal([]byte(`{"$and": [{"$email": ["example.com"]}, {"$not": {"$uuid": null}}]}`))
+	require.NoError(t, err)
+
+	assert.Equal(t, `has suffix "@example.com" and not ^[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}$`, m.Expected())
+
+	result, err := m.Match("john@example.com")
+
+	assert.True(t, result)
+	assert.NoError(t, err)
+
+	data, err := matcher.MarshalJSON(r.MustNew("email", "example.com"))
+	require.NoError(t, err)
+
+	m, err = r.Unmarshal(data)
+	require.NoError(t, err)
+
+	remarshaled, err := matcher.MarshalJSON(m)
+	require.NoError(t, err)
+
+	assert.JSONEq(t, `{"$email": ["example.com"]}`, string(remarshaled))
+
+	_, err = matcher.ParseJSON(data)
+
+	require.ErrorIs(t, err, matcher.ErrUnknownMatcher)
+}
+
+func TestRegistry_Unmarshal_Named(t *testing.T) {
+	t.Parallel()
+
+	r := newTestRegistry(t)
+
+	data, err := matcher.MarshalJSON(matcher.Named("email", "example.com", matcher.HasSuffix("@example.com")))
+	require.NoError(t, err)
+
+	assert.JSONEq(t, `{"$email": "example.com"}`, string(data))
+
+	m, err := r.Unmarshal(data)
+	require.NoError(t, err)
+
+	assert.Equal(t, `has suffix "@example.com"`, m.Expected())
+
+	result, err := m.Match("john@example.com")
+
+	assert.True(t, result)
+	assert.NoError(t, err)
+
+	remarshaled, err := matcher.MarshalJSON(m)
+	require.NoError(t, err)
+
+	assert.JSONEq(t, `{"$email": "example.com"}`, string(remarshaled))
+}
+
+func TestRegistry_Unmarshal_Error(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		scenario      string
+		data          string
+		expectedError string
+	}{
+		{
+			scenario:      "unknown matcher",
+			data:          `{"$iso8601": null}`,
+			expectedError: `$iso8601: unknown matcher`,
+		},
+		{
+			scenario:      "factory error",
+			data:          `{"$or": [{"$uuid": null}, {"$email": ["a", "b"]}]}`,
+			expectedError: `$or: [1]: $email: too many arguments`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.scenario, func(t *testing.T) {
+			t.Parallel()
+
+			m, err := newTestRegistry(t).Unmarshal([]byte(tc.data))
+
+			assert.Nil(t, m)
+			require.EqualError(t, err, tc.expectedError)
+		})
+	}
+}
+
+func TestRegistry_Lookup(t *testing.T) {
+	t.Parallel()
+
+	var r matcher.Registry
+
+	assert.False(t, r.Has("uuid"))
+	assert.Empty(t, r.Names())
+
+	f, err := r.Lookup("uuid")
+
+	assert.Nil(t, f)
+	require.ErrorIs(t, err, matcher.ErrUnknownMatcher)
+
+	r.MustRegister("uuid", func(...any) (matcher.Matcher, error) { return matcher.Any, nil })
+	r.MustRegister("email", func(...any) (matcher.Matcher, error) { return matcher.Any, nil })
+
+	f, err = r.Lookup("uuid")
+
+	assert.NotNil(t, f)
+	require.NoError(t, err)
+
+	assert.True(t, r.Has("uuid"))
+	assert.Equal(t, []string{"email", "uuid"}, r.Names())
+}
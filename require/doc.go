@@ -0,0 +1,2 @@
+// Package require provides the same assertions as the assert package, but stops the test when an assertion fails.
+package require
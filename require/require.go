@@ -0,0 +1,30 @@
+package require
+
+import (
+	"go.nhat.io/matcher/v3"
+	"go.nhat.io/matcher/v3/assert"
+)
+
+// TestingT is an interface wrapper around *testing.T.
+type TestingT interface {
+	Errorf(format string, args ...any)
+	FailNow()
+}
+
+type tHelper interface {
+	Helper()
+}
+
+// That asserts that the actual matches the matcher, and stops the test if it does not. See assert.That for the failure
+// message.
+func That(t TestingT, actual any, m matcher.Matcher, msgAndArgs ...any) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+
+	if assert.That(t, actual, m, msgAndArgs...) {
+		return
+	}
+
+	t.FailNow()
+}
@@ -0,0 +1,57 @@
+package require_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"go.nhat.io/matcher/v3"
+	"go.nhat.io/matcher/v3/require"
+)
+
+type testingT struct {
+	errors []string
+	failed bool
+}
+
+func (t *testingT) Errorf(format string, args ...any) {
+	t.errors = append(t.errors, fmt.Sprintf(format, args...))
+}
+
+func (t *testingT) FailNow() {
+	t.failed = true
+}
+
+func TestThat(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		scenario       string
+		actual         any
+		expectedFailed bool
+	}{
+		{
+			scenario: "matched",
+			actual:   "foobar",
+		},
+		{
+			scenario:       "not matched",
+			actual:         "foo",
+			expectedFailed: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.scenario, func(t *testing.T) {
+			t.Parallel()
+
+			tt := &testingT{}
+
+			require.That(tt, tc.actual, matcher.Equal("foobar"))
+
+			assert.Equal(t, tc.expectedFailed, tt.failed)
+			assert.Equal(t, tc.expectedFailed, len(tt.errors) == 1)
+		})
+	}
+}
@@ -0,0 +1,4 @@
+// Package spec provides a parser for the matcher expressions, like `regex:^foo`, `len:3` or
+// `and(prefix:"a", not(empty))`, so the matchers could be written in the fixture files. The application-specific
+// matchers, like `uuid`, could be used by registering them in a matcher.Registry, see WithRegistry().
+package spec
@@ -0,0 +1,18 @@
+package spec
+
+import (
+	"strconv"
+)
+
+// SyntaxError is returned when an expression is invalid.
+type SyntaxError struct {
+	// Column is the position of the error in the expression, the first character is at column 1.
+	Column int
+	// Message describes the error.
+	Message string
+}
+
+// Error returns the error message.
+func (e *SyntaxError) Error() string {
+	return "spec: column " + strconv.Itoa(e.Column) + ": " + e.Message
+}
@@ -0,0 +1,15 @@
+package spec
+
+import "go.nhat.io/matcher/v3"
+
+// Option configures the parser.
+type Option func(p *parser)
+
+// WithRegistry lets the expression use the names in the registry, like `uuid` or `email:example.com`. A name without
+// a value creates the matcher without any arguments, and a name with a value creates it with the value, as a string.
+// The registry rejects the built-in names, see matcher.Registry.Register().
+func WithRegistry(r *matcher.Registry) Option {
+	return func(p *parser) {
+		p.registry = r
+	}
+}
@@ -0,0 +1,510 @@
+package spec
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"go.nhat.io/matcher/v3"
+)
+
+var (
+	errInvalidLength  = errors.New("invalid length")
+	errNegativeLength = errors.New("length must not be negative")
+	errInvalidJSON    = errors.New("invalid json")
+	errInvalidBetween = errors.New("expected [lower, upper] of two numbers or two strings")
+)
+
+// The names are the operators of matcher.MarshalJSON(), so that an expression like `len:3` is {"$len": 3} in JSON,
+// and `not(prefix:a)` is {"$not": {"$prefix": "a"}}.
+
+// constants are the matchers without a value, like `empty`.
+var constants = map[string]func() matcher.Matcher{
+	"any":      func() matcher.Matcher { return matcher.Any },
+	"empty":    matcher.IsEmpty,
+	"notEmpty": matcher.IsNotEmpty,
+}
+
+// values are the matchers with a value, like `regex:^foo`.
+var values = map[string]func(value string) (matcher.Matcher, error){
+	"eq":        func(v string) (matcher.Matcher, error) { return matcher.Equal(v), nil },
+	"prefix":    func(v string) (matcher.Matcher, error) { return matcher.HasPrefix(v), nil },
+	"suffix":    func(v string) (matcher.Matcher, error) { return matcher.HasSuffix(v), nil },
+	"equalFold": func(v string) (matcher.Matcher, error) { return matcher.EqualFold(v), nil },
+	"contains":  func(v string) (matcher.Matcher, error) { return matcher.Contains(v), nil },
+	"wildcard":  func(v string) (matcher.Matcher, error) { return matcher.Wildcard(v), nil },
+	"regex": func(v string) (matcher.Matcher, error) {
+		r, err := regexp.Compile(v)
+		if err != nil {
+			return nil, err
+		}
+
+		return matcher.Regex(r), nil
+	},
+	"len":     lenValue(matcher.Len[int]),
+	"runeLen": lenValue(matcher.RuneLen[int]),
+	"gt":      orderedValue(matcher.GreaterThan[float64], matcher.GreaterThan[string]),
+	"gte":     orderedValue(matcher.GreaterOrEqual[float64], matcher.GreaterOrEqual[string]),
+	"lt":      orderedValue(matcher.LessThan[float64], matcher.LessThan[string]),
+	"lte":     orderedValue(matcher.LessOrEqual[float64], matcher.LessOrEqual[string]),
+	"between": betweenValue,
+	"json": func(v string) (matcher.Matcher, error) {
+		if !json.Valid([]byte(v)) {
+			return nil, errInvalidJSON
+		}
+
+		return matcher.JSON(v), nil
+	},
+}
+
+// functions are the matchers with nested matchers, like `not(empty)`. The argument counts are inclusive, -1 means no
+// limit. The quoted strings are kept as strings in the arguments of the contains matchers, so that they are still
+// matched as substrings, instead of being converted to matcher.Equal.
+var functions = map[string]struct {
+	minArgs, maxArgs int
+	elements         bool
+	build            func(args ...any) matcher.Matcher
+}{
+	"and":         {minArgs: 1, maxArgs: -1, build: matcher.And},
+	"or":          {minArgs: 1, maxArgs: -1, build: matcher.Or},
+	"not":         {minArgs: 1, maxArgs: 1, build: func(args ...any) matcher.Matcher { return matcher.Not(args[0]) }},
+	"len":         {minArgs: 1, maxArgs: 1, build: func(args ...any) matcher.Matcher { return matcher.LenMatches(args[0]) }},
+	"runeLen":     {minArgs: 1, maxArgs: 1, build: func(args ...any) matcher.Matcher { return matcher.RuneLenMatches(args[0]) }},
+	"contains":    {minArgs: 1, maxArgs: 1, elements: true, build: func(args ...any) matcher.Matcher { return matcher.Contains(args[0]) }},
+	"containsAll": {minArgs: 1, maxArgs: -1, elements: true, build: matcher.ContainsAll},
+	"containsAny": {minArgs: 1, maxArgs: -1, elements: true, build: matcher.ContainsAny},
+}
+
+func lenValue(build func(int) matcher.Matcher) func(string) (matcher.Matcher, error) {
+	return func(v string) (matcher.Matcher, error) {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return nil, fmt.Errorf("%w %q", errInvalidLength, v)
+		}
+
+		if n < 0 {
+			return nil, errNegativeLength
+		}
+
+		return build(n), nil
+	}
+}
+
+// orderedValue compares with a number if the value is one, otherwise with a string.
+func orderedValue(number func(float64) matcher.Matcher, str func(string) matcher.Matcher) func(string) (matcher.Matcher, error) {
+	return func(v string) (matcher.Matcher, error) {
+		if n, err := strconv.ParseFloat(v, 64); err == nil {
+			return number(n), nil
+		}
+
+		return str(v), nil
+	}
+}
+
+// betweenValue parses the bounds, like [1, 10] or ["a", "z"].
+func betweenValue(v string) (matcher.Matcher, error) {
+	var bounds []any
+
+	if err := json.Unmarshal([]byte(v), &bounds); err != nil || len(bounds) != 2 {
+		return nil, errInvalidBetween
+	}
+
+	switch lower := bounds[0].(type) {
+	case float64:
+		if upper, ok := bounds[1].(float64); ok {
+			return matcher.Between(lower, upper), nil
+		}
+
+	case string:
+		if upper, ok := bounds[1].(string); ok {
+			return matcher.Between(lower, upper), nil
+		}
+	}
+
+	return nil, errInvalidBetween
+}
+
+// Parse parses an expression and returns the matcher. The expression is one of:
+//
+//   - a quoted string, like "foo" or `foo`, for matcher.Equal.
+//   - a matcher without a value: any, empty, notEmpty.
+//   - a matcher with a value, like prefix:foo or prefix:"foo, bar": eq, prefix, suffix, equalFold, contains, wildcard,
+//     regex, len, runeLen, gt, gte, lt, lte, between, json. The value without quotes ends at the first comma or
+//     closing parenthesis that is not nested in parentheses, brackets or braces, and the spaces around it are trimmed.
+//     The value of gt, gte, lt and lte is compared as a number if it is one, and the value of between is a JSON array
+//     of the bounds, like between:[1, 10].
+//   - a function of expressions: and(...), or(...), not(...), len(...), runeLen(...), contains(...), containsAll(...),
+//     containsAny(...). The quoted strings in the contains functions are matched as substrings.
+//
+// The names are the operators of matcher.MarshalJSON(), with the same arguments, so that `len(gte:2)` is
+// {"$len": {"$gte": 2}}. For example, `and(prefix:"a", not(empty), regex:^a(b|c)$)`. The error is a *SyntaxError with
+// the column of the problem.
+//
+// The names in a registry could be used as well, see WithRegistry().
+func Parse(expr string, opts ...Option) (matcher.Matcher, error) {
+	p := &parser{input: []rune(expr)}
+
+	for _, o := range opts {
+		o(p)
+	}
+
+	m, err := p.parseExpr()
+	if err != nil {
+		return nil, err
+	}
+
+	p.skipSpaces()
+
+	if !p.eof() {
+		return nil, p.errorf(p.pos, "unexpected %q", p.input[p.pos])
+	}
+
+	return m, nil
+}
+
+// MustParse is like Parse, but panics if the expression is invalid.
+func MustParse(expr string, opts ...Option) matcher.Matcher {
+	m, err := Parse(expr, opts...)
+	if err != nil {
+		panic(err)
+	}
+
+	return m
+}
+
+type parser struct {
+	input    []rune
+	pos      int
+	registry *matcher.Registry
+}
+
+// registered determines if a name is in the registry.
+func (p *parser) registered(name string) bool {
+	return p.registry != nil && p.registry.Has(name)
+}
+
+func (p *parser) eof() bool {
+	return p.pos >= len(p.input)
+}
+
+func (p *parser) peek() rune {
+	if p.eof() {
+		return 0
+	}
+
+	return p.input[p.pos]
+}
+
+func (p *parser) skipSpaces() {
+	for !p.eof() && unicode.IsSpace(p.input[p.pos]) {
+		p.pos++
+	}
+}
+
+// errorf returns a syntax error at the position, which is 0-based.
+func (p *parser) errorf(pos int, format string, args ...any) *SyntaxError {
+	return &SyntaxError{Column: pos + 1, Message: fmt.Sprintf(format, args...)}
+}
+
+// unexpected returns a syntax error for the current character.
+func (p *parser) unexpected(expected string) *SyntaxError {
+	if p.eof() {
+		return p.errorf(p.pos, "expected %s, got end of input", expected)
+	}
+
+	return p.errorf(p.pos, "expected %s, got %q", expected, p.input[p.pos])
+}
+
+func (p *parser) parseExpr() (matcher.Matcher, error) {
+	p.skipSpaces()
+
+	if r := p.peek(); r == '"' || r == '`' {
+		s, err := p.parseString()
+		if err != nil {
+			return nil, err
+		}
+
+		return matcher.Equal(s), nil
+	}
+
+	start := p.pos
+	name := p.parseName()
+
+	if name == "" {
+		return nil, p.unexpected("a matcher")
+	}
+
+	switch p.peek() {
+	case '(':
+		return p.parseFunction(name, start)
+
+	case ':':
+		return p.parseValue(name, start)
+	}
+
+	if build, ok := constants[name]; ok {
+		return build(), nil
+	}
+
+	if !p.registered(name) {
+		return nil, p.unknown(name, start)
+	}
+
+	m, err := p.registry.New(name)
+	if err != nil {
+		return nil, p.errorf(start, "%s", err.Error())
+	}
+
+	return m, nil
+}
+
+func (p *parser) parseName() string {
+	start := p.pos
+
+	for !p.eof() {
+		r := p.input[p.pos]
+
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '_' && r != '-' && r != '.' {
+			break
+		}
+
+		p.pos++
+	}
+
+	return string(p.input[start:p.pos])
+}
+
+// unknown returns the error of a matcher that is not used as it should be, or does not exist.
+func (p *parser) unknown(name string, pos int) *SyntaxError {
+	if _, ok := constants[name]; ok {
+		return p.errorf(pos, "%s does not take any value", name)
+	}
+
+	if _, ok := values[name]; ok {
+		return p.errorf(pos, "%s requires a value, like %s:<value>", name, name)
+	}
+
+	if _, ok := functions[name]; ok {
+		return p.errorf(pos, "%s requires arguments, like %s(...)", name, name)
+	}
+
+	if p.registered(name) {
+		return p.errorf(pos, "%s does not take any arguments, like %s or %s:<value>", name, name, name)
+	}
+
+	return p.errorf(pos, "unknown matcher %q", name)
+}
+
+func (p *parser) parseFunction(name string, start int) (matcher.Matcher, error) {
+	fn, ok := functions[name]
+	if !ok {
+		return nil, p.unknown(name, start)
+	}
+
+	// Skip the (.
+	p.pos++
+
+	var args []any
+
+	p.skipSpaces()
+
+	if p.peek() == ')' {
+		p.pos++
+	} else {
+		for {
+			arg, err := p.parseArg(fn.elements)
+			if err != nil {
+				return nil, err
+			}
+
+			args = append(args, arg)
+
+			p.skipSpaces()
+
+			if p.peek() == ')' {
+				p.pos++
+
+				break
+			}
+
+			if p.peek() != ',' {
+				return nil, p.unexpected("',' or ')'")
+			}
+
+			p.pos++
+		}
+	}
+
+	switch {
+	case len(args) < fn.minArgs:
+		return nil, p.errorf(start, "%s requires at least %d argument(s), got %d", name, fn.minArgs, len(args))
+
+	case fn.maxArgs >= 0 && len(args) > fn.maxArgs:
+		return nil, p.errorf(start, "%s accepts at most %d argument(s), got %d", name, fn.maxArgs, len(args))
+	}
+
+	return fn.build(args...), nil
+}
+
+// parseArg parses an argument of a function. A quoted string is kept as a string if the arguments are elements.
+func (p *parser) parseArg(elements bool) (any, error) {
+	p.skipSpaces()
+
+	if r := p.peek(); elements && (r == '"' || r == '`') {
+		return p.parseString()
+	}
+
+	return p.parseExpr()
+}
+
+// value returns the builder of a matcher with a value. The error of the builder has the name of the matcher.
+func (p *parser) value(name string) (func(value string) (matcher.Matcher, error), bool) {
+	if build, ok := values[name]; ok {
+		return func(v string) (matcher.Matcher, error) {
+			m, err := build(v)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", name, err)
+			}
+
+			return m, nil
+		}, true
+	}
+
+	if p.registered(name) {
+		return func(v string) (matcher.Matcher, error) {
+			return p.registry.New(name, v)
+		}, true
+	}
+
+	return nil, false
+}
+
+func (p *parser) parseValue(name string, start int) (matcher.Matcher, error) {
+	build, ok := p.value(name)
+	if !ok {
+		return nil, p.unknown(name, start)
+	}
+
+	// Skip the :.
+	p.pos++
+
+	valuePos := p.pos
+
+	var (
+		value string
+		err   error
+	)
+
+	if r := p.peek(); r == '"' || r == '`' {
+		value, err = p.parseString()
+	} else {
+		value, valuePos, err = p.parseRawValue()
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	m, err := build(value)
+	if err != nil {
+		return nil, p.errorf(valuePos, "%s", err.Error())
+	}
+
+	return m, nil
+}
+
+// parseString parses a double-quoted string with the Go escape sequences, or a back-quoted raw string.
+func (p *parser) parseString() (string, error) {
+	start := p.pos
+	quote := p.input[p.pos]
+
+	for p.pos++; !p.eof(); p.pos++ {
+		r := p.input[p.pos]
+
+		if r == '\\' && quote == '"' {
+			p.pos++
+
+			continue
+		}
+
+		if r != quote {
+			continue
+		}
+
+		p.pos++
+
+		s, err := strconv.Unquote(string(p.input[start:p.pos]))
+		if err != nil {
+			return "", p.errorf(start, "invalid string %s", string(p.input[start:p.pos]))
+		}
+
+		return s, nil
+	}
+
+	return "", p.errorf(start, "unterminated string")
+}
+
+// parseRawValue parses an unquoted value, and returns it with the position of its first non-space character.
+func (p *parser) parseRawValue() (string, int, error) {
+	start := p.pos
+
+	var closers []rune
+
+loop:
+	for ; !p.eof(); p.pos++ {
+		r := p.input[p.pos]
+
+		switch r {
+		case '(':
+			closers = append(closers, ')')
+
+		case '[':
+			closers = append(closers, ']')
+
+		case '{':
+			closers = append(closers, '}')
+
+		case ')', ']', '}':
+			if len(closers) == 0 {
+				if r == ')' {
+					break loop
+				}
+
+				return "", 0, p.errorf(p.pos, "unexpected %q", r)
+			}
+
+			if closers[len(closers)-1] != r {
+				return "", 0, p.errorf(p.pos, "expected %q, got %q", closers[len(closers)-1], r)
+			}
+
+			closers = closers[:len(closers)-1]
+
+		case ',':
+			if len(closers) == 0 {
+				break loop
+			}
+		}
+	}
+
+	if len(closers) > 0 {
+		return "", 0, p.errorf(p.pos, "expected %q, got end of input", closers[len(closers)-1])
+	}
+
+	raw := p.input[start:p.pos]
+
+	for len(raw) > 0 && unicode.IsSpace(raw[0]) {
+		raw = raw[1:]
+		start++
+	}
+
+	value := strings.TrimRightFunc(string(raw), unicode.IsSpace)
+	if value == "" {
+		return "", 0, p.errorf(start, "missing value")
+	}
+
+	return value, start, nil
+}
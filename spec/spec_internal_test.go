@@ -0,0 +1,35 @@
+package spec
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"go.nhat.io/matcher/v3"
+)
+
+func TestRegistry_Register_Reserved(t *testing.T) {
+	t.Parallel()
+
+	var names []string
+
+	for name := range constants {
+		names = append(names, name)
+	}
+
+	for name := range values {
+		names = append(names, name)
+	}
+
+	for name := range functions {
+		names = append(names, name)
+	}
+
+	var r matcher.Registry
+
+	for _, name := range names {
+		err := r.Register(name, func(...any) (matcher.Matcher, error) { return matcher.Any, nil })
+
+		require.ErrorIs(t, err, matcher.ErrDuplicateMatcher, name)
+	}
+}
@@ -0,0 +1,734 @@
+package spec_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.nhat.io/matcher/v3"
+	"go.nhat.io/matcher/v3/spec"
+)
+
+func TestParse(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		scenario         string
+		expr             string
+		expectedExpected string
+		matched          []any
+		notMatched       []any
+	}{
+		{
+			scenario:         "string",
+			expr:             `"foo, bar"`,
+			expectedExpected: "foo, bar",
+			matched:          []any{"foo, bar"},
+			notMatched:       []any{"foo"},
+		},
+		{
+			scenario:         "raw string",
+			expr:             "`foo\\n`",
+			expectedExpected: `foo\n`,
+			matched:          []any{`foo\n`},
+		},
+		{
+			scenario:         "any",
+			expr:             "any",
+			expectedExpected: "is anything",
+			matched:          []any{nil, 42},
+		},
+		{
+			scenario:   "empty",
+			expr:       " empty ",
+			matched:    []any{"", []int{}},
+			notMatched: []any{"foo"},
+		},
+		{
+			scenario:   "notEmpty",
+			expr:       "notEmpty",
+			matched:    []any{"foo"},
+			notMatched: []any{""},
+		},
+		{
+			scenario:         "eq",
+			expr:             "eq:foo",
+			expectedExpected: "foo",
+			matched:          []any{"foo"},
+			notMatched:       []any{"bar"},
+		},
+		{
+			scenario:         "prefix",
+			expr:             `prefix:"a b"`,
+			expectedExpected: `has prefix "a b"`,
+			matched:          []any{"a bc"},
+			notMatched:       []any{"ab"},
+		},
+		{
+			scenario:   "suffix",
+			expr:       "suffix: bar ",
+			matched:    []any{"foobar"},
+			notMatched: []any{"bar "},
+		},
+		{
+			scenario:   "contains",
+			expr:       "contains:oo",
+			matched:    []any{"foo"},
+			notMatched: []any{"bar"},
+		},
+		{
+			scenario:   "equalFold",
+			expr:       "equalFold:Foo",
+			matched:    []any{"FOO", "foo"},
+			notMatched: []any{"bar"},
+		},
+		{
+			scenario:   "wildcard",
+			expr:       "wildcard:foo*bar",
+			matched:    []any{"foo-bar"},
+			notMatched: []any{"foo-baz"},
+		},
+		{
+			scenario:         "regex",
+			expr:             "regex:^a(b|c){1,2}$",
+			expectedExpected: "^a(b|c){1,2}$",
+			matched:          []any{"abc"},
+			notMatched:       []any{"ad"},
+		},
+		{
+			scenario:   "len",
+			expr:       "len:3",
+			matched:    []any{"foo", []int{1, 2, 3}},
+			notMatched: []any{"fo"},
+		},
+		{
+			scenario:   "runeLen",
+			expr:       "runeLen:5",
+			matched:    []any{"héllo"},
+			notMatched: []any{"hello!"},
+		},
+		{
+			scenario:   "len function",
+			expr:       "len(between:[2, 3])",
+			matched:    []any{"fo", "foo"},
+			notMatched: []any{"f", "fooo"},
+		},
+		{
+			scenario:   "runeLen function",
+			expr:       "runeLen(lte:5)",
+			matched:    []any{"héllo"},
+			notMatched: []any{"héllo!"},
+		},
+		{
+			scenario:   "gt and lt",
+			expr:       "and(gt:1, lt:2.5)",
+			matched:    []any{2, 2.4},
+			notMatched: []any{1, 2.5},
+		},
+		{
+			scenario:   "gte and lte",
+			expr:       "and(gte:1, lte:2.5)",
+			matched:    []any{1, 2.5},
+			notMatched: []any{0.9, 3},
+		},
+		{
+			scenario:   "gt string",
+			expr:       "gt:b",
+			matched:    []any{"c"},
+			notMatched: []any{"a"},
+		},
+		{
+			scenario:   "between",
+			expr:       "between:[1, 10]",
+			matched:    []any{1, 10},
+			notMatched: []any{0, 11},
+		},
+		{
+			scenario:   "between strings",
+			expr:       `between:["b", "d"]`,
+			matched:    []any{"b", "c"},
+			notMatched: []any{"a", "e"},
+		},
+		{
+			scenario:   "contains function",
+			expr:       `contains(prefix:a)`,
+			matched:    []any{[]string{"b", "ab"}},
+			notMatched: []any{[]string{"b"}},
+		},
+		{
+			scenario:   "contains function - substring",
+			expr:       `contains("oo")`,
+			matched:    []any{"foo"},
+			notMatched: []any{"bar"},
+		},
+		{
+			scenario:   "containsAll",
+			expr:       `containsAll("a", prefix:b)`,
+			matched:    []any{[]string{"a", "bc"}},
+			notMatched: []any{[]string{"a"}, []string{"bc"}},
+		},
+		{
+			scenario:   "containsAny",
+			expr:       `containsAny("a", "b")`,
+			matched:    []any{[]string{"b"}, "xa"},
+			notMatched: []any{[]string{"c"}, "c"},
+		},
+		{
+			scenario:   "json",
+			expr:       `json:{"id": 1, "tags": ["a", "b"]}`,
+			matched:    []any{`{"tags":["a","b"],"id":1}`},
+			notMatched: []any{`{"id": 1}`},
+		},
+		{
+			scenario:   "and",
+			expr:       `and(prefix:"a", not(empty))`,
+			matched:    []any{"ab"},
+			notMatched: []any{"ba"},
+		},
+		{
+			scenario:   "or",
+			expr:       "or(regex:^a, len:1 , `foo`)",
+			matched:    []any{"ab", "b", "foo"},
+			notMatched: []any{"bar"},
+		},
+		{
+			scenario:   "nested",
+			expr:       `not(or(eq:a, and(prefix:b, suffix:c)))`,
+			matched:    []any{"bd"},
+			notMatched: []any{"a", "bc"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.scenario, func(t *testing.T) {
+			t.Parallel()
+
+			m, err := spec.Parse(tc.expr)
+			require.NoError(t, err)
+
+			if tc.expectedExpected != "" {
+				assert.Equal(t, tc.expectedExpected, m.Expected())
+			}
+
+			for _, v := range tc.matched {
+				result, err := m.Match(v)
+
+				assert.True(t, result, "%#v", v)
+				assert.NoError(t, err)
+			}
+
+			for _, v := range tc.notMatched {
+				result, _ := m.Match(v) //nolint: errcheck
+
+				assert.False(t, result, "%#v", v)
+			}
+		})
+	}
+}
+
+func TestParse_Error(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		scenario       string
+		expr           string
+		expectedColumn int
+		expectedError  string
+	}{
+		{
+			scenario:       "empty",
+			expr:           "",
+			expectedColumn: 1,
+			expectedError:  `spec: column 1: expected a matcher, got end of input`,
+		},
+		{
+			scenario:       "unexpected character",
+			expr:           "and(empty, !)",
+			expectedColumn: 12,
+			expectedError:  `spec: column 12: expected a matcher, got '!'`,
+		},
+		{
+			scenario:       "unknown matcher",
+			expr:           "and(empty, uuid)",
+			expectedColumn: 12,
+			expectedError:  `spec: column 12: unknown matcher "uuid"`,
+		},
+		{
+			scenario:       "unknown function",
+			expr:           "xor(empty)",
+			expectedColumn: 1,
+			expectedError:  `spec: column 1: unknown matcher "xor"`,
+		},
+		{
+			scenario:       "missing value",
+			expr:           "not(regex)",
+			expectedColumn: 5,
+			expectedError:  `spec: column 5: regex requires a value, like regex:<value>`,
+		},
+		{
+			scenario:       "empty value",
+			expr:           "not(prefix: )",
+			expectedColumn: 13,
+			expectedError:  `spec: column 13: missing value`,
+		},
+		{
+			scenario:       "value for a constant",
+			expr:           "empty:foo",
+			expectedColumn: 1,
+			expectedError:  `spec: column 1: empty does not take any value`,
+		},
+		{
+			scenario:       "function without arguments",
+			expr:           "and",
+			expectedColumn: 1,
+			expectedError:  `spec: column 1: and requires arguments, like and(...)`,
+		},
+		{
+			scenario:       "too few arguments",
+			expr:           "or(eq:a, and())",
+			expectedColumn: 10,
+			expectedError:  `spec: column 10: and requires at least 1 argument(s), got 0`,
+		},
+		{
+			scenario:       "too many arguments",
+			expr:           "not(empty, any)",
+			expectedColumn: 1,
+			expectedError:  `spec: column 1: not accepts at most 1 argument(s), got 2`,
+		},
+		{
+			scenario:       "unterminated function",
+			expr:           "and(empty",
+			expectedColumn: 10,
+			expectedError:  `spec: column 10: expected ',' or ')', got end of input`,
+		},
+		{
+			scenario:       "missing comma",
+			expr:           "and(empty any)",
+			expectedColumn: 11,
+			expectedError:  `spec: column 11: expected ',' or ')', got 'a'`,
+		},
+		{
+			scenario:       "trailing characters",
+			expr:           "empty)",
+			expectedColumn: 6,
+			expectedError:  `spec: column 6: unexpected ')'`,
+		},
+		{
+			scenario:       "unterminated string",
+			expr:           `and(eq:"foo)`,
+			expectedColumn: 8,
+			expectedError:  `spec: column 8: unterminated string`,
+		},
+		{
+			scenario:       "invalid string",
+			expr:           `"\q"`,
+			expectedColumn: 1,
+			expectedError:  `spec: column 1: invalid string "\q"`,
+		},
+		{
+			scenario:       "unbalanced value",
+			expr:           "regex:^a(b",
+			expectedColumn: 11,
+			expectedError:  `spec: column 11: expected ')', got end of input`,
+		},
+		{
+			scenario:       "mismatched value",
+			expr:           "regex:^a(b]",
+			expectedColumn: 11,
+			expectedError:  `spec: column 11: expected ')', got ']'`,
+		},
+		{
+			scenario:       "invalid regex",
+			expr:           "and(empty, regex:  a**)",
+			expectedColumn: 20,
+			expectedError:  "spec: column 20: regex: error parsing regexp: invalid nested repetition operator: `**`",
+		},
+		{
+			scenario:       "invalid length",
+			expr:           "len:three",
+			expectedColumn: 5,
+			expectedError:  `spec: column 5: len: invalid length "three"`,
+		},
+		{
+			scenario:       "negative length",
+			expr:           "len:-1",
+			expectedColumn: 5,
+			expectedError:  `spec: column 5: len: length must not be negative`,
+		},
+		{
+			scenario:       "invalid json",
+			expr:           "json:{id}",
+			expectedColumn: 6,
+			expectedError:  `spec: column 6: json: invalid json`,
+		},
+		{
+			scenario:       "invalid between",
+			expr:           "between:[1]",
+			expectedColumn: 9,
+			expectedError:  `spec: column 9: between: expected [lower, upper] of two numbers or two strings`,
+		},
+		{
+			scenario:       "mixed between",
+			expr:           `between:[1, "z"]`,
+			expectedColumn: 9,
+			expectedError:  `spec: column 9: between: expected [lower, upper] of two numbers or two strings`,
+		},
+		{
+			scenario:       "unknown lowercase name",
+			expr:           "notempty",
+			expectedColumn: 1,
+			expectedError:  `spec: column 1: unknown matcher "notempty"`,
+		},
+		{
+			scenario:       "unicode column",
+			expr:           `and(eq:"héllo", ?)`,
+			expectedColumn: 17,
+			expectedError:  `spec: column 17: expected a matcher, got '?'`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.scenario, func(t *testing.T) {
+			t.Parallel()
+
+			m, err := spec.Parse(tc.expr)
+
+			assert.Nil(t, m)
+			require.EqualError(t, err, tc.expectedError)
+
+			var serr *spec.SyntaxError
+
+			require.ErrorAs(t, err, &serr)
+			assert.Equal(t, tc.expectedColumn, serr.Column)
+		})
+	}
+}
+
+func TestMustParse(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, `has prefix "foo"`, spec.MustParse("prefix:foo").Expected())
+
+	assert.PanicsWithError(t, `spec: column 1: unknown matcher "foo"`, func() {
+		spec.MustParse("foo")
+	})
+}
+
+func TestParse_WithRegistry(t *testing.T) {
+	t.Parallel()
+
+	var r matcher.Registry
+
+	r.MustRegister("uuid", func(...any) (matcher.Matcher, error) {
+		return matcher.Regex("^[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}$"), nil
+	})
+
+	r.MustRegister("domain", func(args ...any) (matcher.Matcher, error) {
+		if len(args) != 1 {
+			return nil, errors.New("requires a domain")
+		}
+
+		return matcher.HasSuffix(fmt.Sprintf("@%s", args[0])), nil
+	})
+
+	err := r.Register("notEmpty", func(...any) (matcher.Matcher, error) { return matcher.Any, nil })
+
+	require.ErrorIs(t, err, matcher.ErrDuplicateMatcher)
+
+	m, err := spec.Parse(`and(uuid, notEmpty)`, spec.WithRegistry(&r))
+	require.NoError(t, err)
+
+	result, err := m.Match("3f2504e0-4f89-11d3-9a0c-0305e82c3301")
+
+	assert.True(t, result)
+	assert.NoError(t, err)
+
+	result, _ = m.Match("") //nolint: errcheck
+
+	assert.False(t, result)
+
+	m = spec.MustParse(`or(domain:example.com, domain:"example.org")`, spec.WithRegistry(&r))
+
+	for _, v := range []string{"john@example.com", "john@example.org"} {
+		result, err := m.Match(v)
+
+		assert.True(t, result, v)
+		assert.NoError(t, err)
+	}
+
+	result, _ = m.Match("john@example.net") //nolint: errcheck
+
+	assert.False(t, result)
+}
+
+func TestParse_WithRegistry_Error(t *testing.T) {
+	t.Parallel()
+
+	var r matcher.Registry
+
+	r.MustRegister("domain", func(args ...any) (matcher.Matcher, error) {
+		if len(args) != 1 {
+			return nil, errors.New("requires a domain")
+		}
+
+		return matcher.HasSuffix(fmt.Sprintf("@%s", args[0])), nil
+	})
+
+	testCases := []struct {
+		scenario       string
+		expr           string
+		expectedColumn int
+		expectedError  string
+	}{
+		{
+			scenario:       "unknown matcher",
+			expr:           "and(empty, uuid)",
+			expectedColumn: 12,
+			expectedError:  `spec: column 12: unknown matcher "uuid"`,
+		},
+		{
+			scenario:       "factory error",
+			expr:           "not(domain)",
+			expectedColumn: 5,
+			expectedError:  `spec: column 5: domain: requires a domain`,
+		},
+		{
+			scenario:       "arguments",
+			expr:           "domain(empty)",
+			expectedColumn: 1,
+			expectedError:  `spec: column 1: domain does not take any arguments, like domain or domain:<value>`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.scenario, func(t *testing.T) {
+			t.Parallel()
+
+			m, err := spec.Parse(tc.expr, spec.WithRegistry(&r))
+
+			assert.Nil(t, m)
+			require.EqualError(t, err, tc.expectedError)
+
+			var serr *spec.SyntaxError
+
+			require.ErrorAs(t, err, &serr)
+			assert.Equal(t, tc.expectedColumn, serr.Column)
+		})
+	}
+}
+
+func TestParse_MarshalJSON(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		expr     string
+		expected string
+		matched  []any
+	}{
+		{
+			expr:     `"foo"`,
+			expected: `{"$eq": "foo"}`,
+			matched:  []any{"foo"},
+		},
+		{
+			expr:     "any",
+			expected: `{"$any": true}`,
+			matched:  []any{42},
+		},
+		{
+			expr:     "empty",
+			expected: `{"$empty": true}`,
+			matched:  []any{""},
+		},
+		{
+			expr:     "notEmpty",
+			expected: `{"$notEmpty": true}`,
+			matched:  []any{"foo"},
+		},
+		{
+			expr:     "eq:foo",
+			expected: `{"$eq": "foo"}`,
+			matched:  []any{"foo"},
+		},
+		{
+			expr:     "prefix:foo",
+			expected: `{"$prefix": "foo"}`,
+			matched:  []any{"foobar"},
+		},
+		{
+			expr:     "suffix:bar",
+			expected: `{"$suffix": "bar"}`,
+			matched:  []any{"foobar"},
+		},
+		{
+			expr:     "equalFold:FOO",
+			expected: `{"$equalFold": "FOO"}`,
+			matched:  []any{"foo"},
+		},
+		{
+			expr:     "contains:oo",
+			expected: `{"$contains": "oo"}`,
+			matched:  []any{"foo"},
+		},
+		{
+			expr:     "wildcard:foo*bar",
+			expected: `{"$regex": "^foo.*bar$"}`,
+			matched:  []any{"foo-bar"},
+		},
+		{
+			expr:     "regex:^a(b|c)$",
+			expected: `{"$regex": "^a(b|c)$"}`,
+			matched:  []any{"ab"},
+		},
+		{
+			expr:     "len:3",
+			expected: `{"$len": 3}`,
+			matched:  []any{"foo"},
+		},
+		{
+			expr:     "runeLen:5",
+			expected: `{"$runeLen": 5}`,
+			matched:  []any{"héllo"},
+		},
+		{
+			expr:     "len(gte:2)",
+			expected: `{"$len": {"$gte": 2}}`,
+			matched:  []any{"fo", "foo"},
+		},
+		{
+			expr:     "runeLen(lte:5)",
+			expected: `{"$runeLen": {"$lte": 5}}`,
+			matched:  []any{"héllo"},
+		},
+		{
+			expr:     "gt:1",
+			expected: `{"$gt": 1}`,
+			matched:  []any{2},
+		},
+		{
+			expr:     "gte:1.5",
+			expected: `{"$gte": 1.5}`,
+			matched:  []any{1.5},
+		},
+		{
+			expr:     "lt:b",
+			expected: `{"$lt": "b"}`,
+			matched:  []any{"a"},
+		},
+		{
+			expr:     "lte:2",
+			expected: `{"$lte": 2}`,
+			matched:  []any{2},
+		},
+		{
+			expr:     "between:[1, 10]",
+			expected: `{"$between": [1, 10]}`,
+			matched:  []any{5},
+		},
+		{
+			expr:     "contains(prefix:a)",
+			expected: `{"$contains": {"$prefix": "a"}}`,
+			matched:  []any{[]string{"ab"}},
+		},
+		{
+			expr:     `containsAll("a", gt:1)`,
+			expected: `{"$containsAll": ["a", {"$gt": 1}]}`,
+			matched:  []any{[]any{"a", 2}},
+		},
+		{
+			expr:     `containsAny("a", "b")`,
+			expected: `{"$containsAny": ["a", "b"]}`,
+			matched:  []any{"xb"},
+		},
+		{
+			expr:     `json:{"id":1}`,
+			expected: `{"$json":{"id":1}}`,
+			matched:  []any{`{"id":1}`},
+		},
+		{
+			expr:     `and(prefix:a, not(or(empty, contains:z)))`,
+			expected: `{"$and": [{"$prefix": "a"}, {"$not": {"$or": [{"$empty": true}, {"$contains": "z"}]}}]}`,
+			matched:  []any{"ab"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.expr, func(t *testing.T) {
+			t.Parallel()
+
+			m, err := spec.Parse(tc.expr)
+			require.NoError(t, err)
+
+			data, err := matcher.MarshalJSON(m)
+			require.NoError(t, err)
+
+			assert.JSONEq(t, tc.expected, string(data))
+
+			decoded, err := matcher.ParseJSON([]byte(tc.expected))
+			require.NoError(t, err)
+
+			assert.Equal(t, m.Expected(), decoded.Expected())
+
+			for _, v := range tc.matched {
+				result, err := decoded.Match(v)
+
+				assert.True(t, result, "%#v", v)
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestParse_WithRegistry_MarshalJSON(t *testing.T) {
+	t.Parallel()
+
+	var r matcher.Registry
+
+	r.MustRegister("uuid", func(...any) (matcher.Matcher, error) {
+		return matcher.Regex("^[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}$"), nil
+	})
+
+	r.MustRegister("domain", func(args ...any) (matcher.Matcher, error) {
+		if len(args) != 1 {
+			return nil, errors.New("requires a domain")
+		}
+
+		return matcher.HasSuffix(fmt.Sprintf("@%s", args[0])), nil
+	})
+
+	m, err := spec.Parse(`or(uuid, and(domain:example.com, not(empty)))`, spec.WithRegistry(&r))
+	require.NoError(t, err)
+
+	data, err := matcher.MarshalJSON(m)
+	require.NoError(t, err)
+
+	assert.JSONEq(t, `{"$or": [{"$uuid": null}, {"$and": [{"$domain": ["example.com"]}, {"$not": {"$empty": true}}]}]}`, string(data))
+
+	decoded, err := r.Unmarshal(data)
+	require.NoError(t, err)
+
+	assert.Equal(t, m.Expected(), decoded.Expected())
+
+	for _, v := range []string{"3f2504e0-4f89-11d3-9a0c-0305e82c3301", "john@example.com"} {
+		result, err := decoded.Match(v)
+
+		assert.True(t, result, v)
+		assert.NoError(t, err)
+	}
+
+	result, _ := decoded.Match("john@example.org") //nolint: errcheck
+
+	assert.False(t, result)
+
+	remarshaled, err := matcher.MarshalJSON(decoded)
+	require.NoError(t, err)
+
+	assert.JSONEq(t, string(data), string(remarshaled))
+
+	_, err = matcher.ParseJSON(data)
+
+	require.ErrorIs(t, err, matcher.ErrUnknownMatcher)
+}
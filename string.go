@@ -0,0 +1,89 @@
+package matcher
+
+import (
+	"fmt"
+	"strings"
+)
+
+var (
+	_ Matcher   = (*stringMatcher)(nil)
+	_ Explainer = (*stringMatcher)(nil)
+)
+
+// stringMatcher matches a string.
+type stringMatcher struct {
+	expected string
+	match    func(actual string) bool
+	operator string
+	value    string
+}
+
+// Match determines if the actual is expected.
+func (m stringMatcher) Match(actual any) (bool, error) {
+	s := textVal(actual)
+	if s == nil {
+		return false, nil
+	}
+
+	return m.match(*s), nil
+}
+
+// Expected returns the expectation.
+func (m stringMatcher) Expected() string {
+	return m.expected
+}
+
+// Explain explains why the actual is not expected.
+func (m stringMatcher) Explain(actual any) *Mismatch {
+	if textVal(actual) == nil {
+		return newMismatch(m, actual, fmt.Sprintf("got %T, not a string", actual), nil)
+	}
+
+	return explain(m, actual, "")
+}
+
+func (m stringMatcher) Format(s fmt.State, _ rune) {
+	_, _ = fmt.Fprintf(s, "<%s>", m.expected) //nolint: errcheck
+}
+
+// HasPrefix matches if the actual begins with the prefix.
+//
+// The actual could be a string, a []byte, a fmt.Stringer or an error.
+func HasPrefix[T ~string](prefix T) Matcher {
+	return stringMatcher{
+		expected: fmt.Sprintf("has prefix %q", prefix),
+		operator: operatorHasPrefix,
+		value:    string(prefix),
+		match: func(actual string) bool {
+			return strings.HasPrefix(actual, string(prefix))
+		},
+	}
+}
+
+// HasSuffix matches if the actual ends with the suffix.
+//
+// See HasPrefix for the supported actual values.
+func HasSuffix[T ~string](suffix T) Matcher {
+	return stringMatcher{
+		expected: fmt.Sprintf("has suffix %q", suffix),
+		operator: operatorHasSuffix,
+		value:    string(suffix),
+		match: func(actual string) bool {
+			return strings.HasSuffix(actual, string(suffix))
+		},
+	}
+}
+
+// EqualFold matches if the actual is equal to the expectation, ignoring case.
+//
+// See HasPrefix for the supported actual values.
+func EqualFold[T ~string](expected T) Matcher {
+	return stringMatcher{
+		expected: fmt.Sprintf("equals %q ignoring case", expected),
+		operator: operatorEqualFold,
+		value:    string(expected),
+		match: func(actual string) bool {
+			return strings.EqualFold(actual, string(expected))
+		},
+	}
+}
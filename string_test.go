@@ -0,0 +1,241 @@
+package matcher_test
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.nhat.io/matcher/v3"
+)
+
+type stringError struct {
+	message string
+}
+
+func (e *stringError) Error() string {
+	return e.message
+}
+
+func TestString_Match(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		scenario string
+		matcher  matcher.Matcher
+		actual   any
+		expected bool
+	}{
+		{
+			scenario: "prefix - string",
+			matcher:  matcher.HasPrefix("foo"),
+			actual:   "foobar",
+			expected: true,
+		},
+		{
+			scenario: "prefix - []byte",
+			matcher:  matcher.HasPrefix("foo"),
+			actual:   []byte("foobar"),
+			expected: true,
+		},
+		{
+			scenario: "prefix - stringer",
+			matcher:  matcher.HasPrefix("UT"),
+			actual:   time.UTC,
+			expected: true,
+		},
+		{
+			scenario: "prefix - error",
+			matcher:  matcher.HasPrefix("foo"),
+			actual:   errors.New("foo: bar"),
+			expected: true,
+		},
+		{
+			scenario: "prefix - nil stringer",
+			matcher:  matcher.HasPrefix("foo"),
+			actual:   (*url.URL)(nil),
+		},
+		{
+			scenario: "prefix - nil error",
+			matcher:  matcher.HasPrefix("foo"),
+			actual:   (*stringError)(nil),
+		},
+		{
+			scenario: "prefix - mismatched",
+			matcher:  matcher.HasPrefix("bar"),
+			actual:   "foobar",
+		},
+		{
+			scenario: "prefix - not a string",
+			matcher:  matcher.HasPrefix("foo"),
+			actual:   42,
+		},
+		{
+			scenario: "prefix - nil",
+			matcher:  matcher.HasPrefix("foo"),
+			actual:   nil,
+		},
+		{
+			scenario: "suffix",
+			matcher:  matcher.HasSuffix("bar"),
+			actual:   "foobar",
+			expected: true,
+		},
+		{
+			scenario: "suffix - mismatched",
+			matcher:  matcher.HasSuffix("foo"),
+			actual:   "foobar",
+		},
+		{
+			scenario: "contains",
+			matcher:  matcher.Contains("oba"),
+			actual:   "foobar",
+			expected: true,
+		},
+		{
+			scenario: "contains - mismatched",
+			matcher:  matcher.Contains("baz"),
+			actual:   "foobar",
+		},
+		{
+			scenario: "contains - nil stringer",
+			matcher:  matcher.Contains("foo"),
+			actual:   (*url.URL)(nil),
+		},
+		{
+			scenario: "contains - nil error",
+			matcher:  matcher.Contains("foo"),
+			actual:   (*stringError)(nil),
+		},
+		{
+			scenario: "contains all",
+			matcher:  matcher.ContainsAll("foo", "bar"),
+			actual:   "foobar",
+			expected: true,
+		},
+		{
+			scenario: "contains all - mismatched",
+			matcher:  matcher.ContainsAll("foo", "baz"),
+			actual:   "foobar",
+		},
+		{
+			scenario: "contains any",
+			matcher:  matcher.ContainsAny("baz", "bar"),
+			actual:   "foobar",
+			expected: true,
+		},
+		{
+			scenario: "contains any - mismatched",
+			matcher:  matcher.ContainsAny("baz", "qux"),
+			actual:   "foobar",
+		},
+		{
+			scenario: "equal fold",
+			matcher:  matcher.EqualFold("FooBar"),
+			actual:   "FOOBAR",
+			expected: true,
+		},
+		{
+			scenario: "equal fold - mismatched",
+			matcher:  matcher.EqualFold("FooBar"),
+			actual:   "FOOBAZ",
+		},
+		{
+			scenario: "logical",
+			matcher:  matcher.And(matcher.HasPrefix("foo"), matcher.Not(matcher.HasSuffix("baz"))),
+			actual:   "foobar",
+			expected: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.scenario, func(t *testing.T) {
+			t.Parallel()
+
+			result, err := tc.matcher.Match(tc.actual)
+
+			assert.Equal(t, tc.expected, result)
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestString_Expected(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		scenario string
+		matcher  matcher.Matcher
+		expected string
+	}{
+		{
+			scenario: "prefix",
+			matcher:  matcher.HasPrefix("foo"),
+			expected: `has prefix "foo"`,
+		},
+		{
+			scenario: "suffix",
+			matcher:  matcher.HasSuffix("foo"),
+			expected: `has suffix "foo"`,
+		},
+		{
+			scenario: "contains",
+			matcher:  matcher.Contains("foo"),
+			expected: `contains "foo"`,
+		},
+		{
+			scenario: "contains all",
+			matcher:  matcher.ContainsAll("foo", "bar"),
+			expected: `contains all of ["foo", "bar"]`,
+		},
+		{
+			scenario: "contains any",
+			matcher:  matcher.ContainsAny("foo", "bar"),
+			expected: `contains any of ["foo", "bar"]`,
+		},
+		{
+			scenario: "equal fold",
+			matcher:  matcher.EqualFold("foo"),
+			expected: `equals "foo" ignoring case`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.scenario, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tc.expected, tc.matcher.Expected())
+		})
+	}
+}
+
+func TestStringMatcher_Format(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, `<has prefix "foo">`, fmt.Sprintf("%#v", matcher.HasPrefix("foo")))
+}
+
+func TestString_Explain(t *testing.T) {
+	t.Parallel()
+
+	actual := matcher.Explain(matcher.HasPrefix("foo"), 42)
+
+	require.NotNil(t, actual)
+	assert.Equal(t, `expected has prefix "foo", got int, not a string`, actual.String())
+
+	actual = matcher.Explain(matcher.HasPrefix("foo"), "bar")
+
+	require.NotNil(t, actual)
+	assert.Equal(t, `expected has prefix "foo", got string("bar")`, actual.String())
+
+	actual = matcher.Explain(matcher.HasSuffix("foo"), (*stringError)(nil))
+
+	require.NotNil(t, actual)
+	assert.Equal(t, `expected has suffix "foo", got *matcher_test.stringError, not a string`, actual.String())
+
+	assert.Nil(t, matcher.Explain(matcher.HasPrefix("foo"), "foobar"))
+}
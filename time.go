@@ -0,0 +1,122 @@
+package matcher
+
+import (
+	"fmt"
+	"time"
+)
+
+var (
+	_ Matcher   = (*timeMatcher)(nil)
+	_ Explainer = (*timeMatcher)(nil)
+)
+
+// timeMatcher matches a point in time.
+type timeMatcher struct {
+	expected string
+	match    func(actual time.Time) bool
+	operator string
+	value    any
+}
+
+// Match determines if the actual is expected.
+func (m timeMatcher) Match(actual any) (bool, error) {
+	t, ok := timeVal(actual)
+	if !ok {
+		return false, nil
+	}
+
+	return m.match(t), nil
+}
+
+// Expected returns the expectation.
+func (m timeMatcher) Expected() string {
+	return m.expected
+}
+
+// Explain explains why the actual is not expected.
+func (m timeMatcher) Explain(actual any) *Mismatch {
+	if _, ok := timeVal(actual); !ok {
+		return newMismatch(m, actual, fmt.Sprintf("got %T, not a time", actual), nil)
+	}
+
+	return explain(m, actual, "")
+}
+
+func (m timeMatcher) Format(s fmt.State, _ rune) {
+	_, _ = fmt.Fprintf(s, "<%s>", m.expected) //nolint: errcheck
+}
+
+func formatTime(t time.Time) string {
+	return t.Format(time.RFC3339Nano)
+}
+
+// TimeEqual matches if the actual is the same instant as the expectation, regardless of the location and the monotonic
+// clock reading.
+//
+// The actual could be a time.Time, a *time.Time, or a RFC3339 string or []byte, quoted or not.
+func TimeEqual(expected time.Time) Matcher {
+	return timeMatcher{
+		expected: "time is " + formatTime(expected),
+		match:    expected.Equal,
+		operator: operatorTimeEqual,
+		value:    expected,
+	}
+}
+
+// WithinDuration matches if the actual is within the duration of the expectation, inclusively.
+//
+// See TimeEqual for the supported actual values.
+func WithinDuration(expected time.Time, delta time.Duration) Matcher {
+	return timeMatcher{
+		expected: fmt.Sprintf("time is within %s of %s", delta, formatTime(expected)),
+		match: func(actual time.Time) bool {
+			d := actual.Sub(expected)
+
+			return d >= -delta && d <= delta
+		},
+		operator: operatorWithinDuration,
+		value:    []any{expected, delta.String()},
+	}
+}
+
+// Before matches if the actual is before the expectation.
+//
+// See TimeEqual for the supported actual values.
+func Before(expected time.Time) Matcher {
+	return timeMatcher{
+		expected: "time is before " + formatTime(expected),
+		match: func(actual time.Time) bool {
+			return actual.Before(expected)
+		},
+		operator: operatorBefore,
+		value:    expected,
+	}
+}
+
+// After matches if the actual is after the expectation.
+//
+// See TimeEqual for the supported actual values.
+func After(expected time.Time) Matcher {
+	return timeMatcher{
+		expected: "time is after " + formatTime(expected),
+		match: func(actual time.Time) bool {
+			return actual.After(expected)
+		},
+		operator: operatorAfter,
+		value:    expected,
+	}
+}
+
+// TimeBetween matches if the actual is between lower and upper, inclusively.
+//
+// See TimeEqual for the supported actual values.
+func TimeBetween(lower, upper time.Time) Matcher {
+	return timeMatcher{
+		expected: fmt.Sprintf("time is between %s and %s", formatTime(lower), formatTime(upper)),
+		match: func(actual time.Time) bool {
+			return !actual.Before(lower) && !actual.After(upper)
+		},
+		operator: operatorTimeBetween,
+		value:    []any{lower, upper},
+	}
+}
@@ -0,0 +1,230 @@
+package matcher_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.nhat.io/matcher/v3"
+)
+
+func TestTime_Match(t *testing.T) {
+	t.Parallel()
+
+	instant := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	local := instant.In(time.FixedZone("UTC+7", 7*3600))
+	earlier := instant.Add(-time.Second)
+	later := instant.Add(time.Second)
+	now := time.Now()
+
+	payload, err := json.Marshal(local)
+	require.NoError(t, err)
+
+	testCases := []struct {
+		scenario string
+		matcher  matcher.Matcher
+		actual   any
+		expected bool
+	}{
+		{
+			scenario: "equal - same",
+			matcher:  matcher.TimeEqual(instant),
+			actual:   instant,
+			expected: true,
+		},
+		{
+			scenario: "equal - other location",
+			matcher:  matcher.TimeEqual(instant),
+			actual:   local,
+			expected: true,
+		},
+		{
+			scenario: "equal - pointer",
+			matcher:  matcher.TimeEqual(instant),
+			actual:   &local,
+			expected: true,
+		},
+		{
+			scenario: "equal - nil pointer",
+			matcher:  matcher.TimeEqual(instant),
+			actual:   (*time.Time)(nil),
+		},
+		{
+			scenario: "equal - string",
+			matcher:  matcher.TimeEqual(instant),
+			actual:   "2024-01-02T10:04:05+07:00",
+			expected: true,
+		},
+		{
+			scenario: "equal - []byte",
+			matcher:  matcher.TimeEqual(instant),
+			actual:   []byte("2024-01-02T03:04:05Z"),
+			expected: true,
+		},
+		{
+			scenario: "equal - json",
+			matcher:  matcher.TimeEqual(instant),
+			actual:   payload,
+			expected: true,
+		},
+		{
+			scenario: "equal - invalid string",
+			matcher:  matcher.TimeEqual(instant),
+			actual:   "yesterday",
+		},
+		{
+			scenario: "equal - not a time",
+			matcher:  matcher.TimeEqual(instant),
+			actual:   42,
+		},
+		{
+			scenario: "equal - different",
+			matcher:  matcher.TimeEqual(instant),
+			actual:   later,
+		},
+		{
+			scenario: "equal - monotonic",
+			matcher:  matcher.TimeEqual(now.Round(0)),
+			actual:   now,
+			expected: true,
+		},
+		{
+			scenario: "within - inside",
+			matcher:  matcher.WithinDuration(instant, time.Second),
+			actual:   earlier,
+			expected: true,
+		},
+		{
+			scenario: "within - outside",
+			matcher:  matcher.WithinDuration(instant, time.Second),
+			actual:   later.Add(time.Nanosecond),
+		},
+		{
+			scenario: "before - earlier",
+			matcher:  matcher.Before(instant),
+			actual:   earlier,
+			expected: true,
+		},
+		{
+			scenario: "before - same",
+			matcher:  matcher.Before(instant),
+			actual:   instant,
+		},
+		{
+			scenario: "after - later",
+			matcher:  matcher.After(instant),
+			actual:   "2024-01-02T03:04:06Z",
+			expected: true,
+		},
+		{
+			scenario: "after - same",
+			matcher:  matcher.After(instant),
+			actual:   local,
+		},
+		{
+			scenario: "between - lower",
+			matcher:  matcher.TimeBetween(earlier, later),
+			actual:   earlier,
+			expected: true,
+		},
+		{
+			scenario: "between - upper",
+			matcher:  matcher.TimeBetween(earlier, later),
+			actual:   later,
+			expected: true,
+		},
+		{
+			scenario: "between - outside",
+			matcher:  matcher.TimeBetween(earlier, instant),
+			actual:   later,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.scenario, func(t *testing.T) {
+			t.Parallel()
+
+			result, err := tc.matcher.Match(tc.actual)
+
+			assert.Equal(t, tc.expected, result)
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestTime_Expected(t *testing.T) {
+	t.Parallel()
+
+	instant := time.Date(2024, 1, 2, 3, 4, 5, 6, time.UTC)
+	later := instant.Add(time.Hour)
+
+	testCases := []struct {
+		scenario string
+		matcher  matcher.Matcher
+		expected string
+	}{
+		{
+			scenario: "equal",
+			matcher:  matcher.TimeEqual(instant),
+			expected: "time is 2024-01-02T03:04:05.000000006Z",
+		},
+		{
+			scenario: "within",
+			matcher:  matcher.WithinDuration(instant, time.Second),
+			expected: "time is within 1s of 2024-01-02T03:04:05.000000006Z",
+		},
+		{
+			scenario: "before",
+			matcher:  matcher.Before(instant),
+			expected: "time is before 2024-01-02T03:04:05.000000006Z",
+		},
+		{
+			scenario: "after",
+			matcher:  matcher.After(instant),
+			expected: "time is after 2024-01-02T03:04:05.000000006Z",
+		},
+		{
+			scenario: "between",
+			matcher:  matcher.TimeBetween(instant, later),
+			expected: "time is between 2024-01-02T03:04:05.000000006Z and 2024-01-02T04:04:05.000000006Z",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.scenario, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tc.expected, tc.matcher.Expected())
+		})
+	}
+}
+
+func TestTimeMatcher_Format(t *testing.T) {
+	t.Parallel()
+
+	m := matcher.Before(time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC))
+
+	assert.Equal(t, "<time is before 2024-01-02T03:04:05Z>", fmt.Sprintf("%#v", m))
+}
+
+func TestTime_Explain(t *testing.T) {
+	t.Parallel()
+
+	m := matcher.Before(time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC))
+
+	actual := matcher.Explain(m, 42)
+
+	require.NotNil(t, actual)
+	assert.Equal(t, "expected time is before 2024-01-02T03:04:05Z, got int, not a time", actual.String())
+
+	actual = matcher.Explain(m, "2025-01-01T00:00:00Z")
+
+	require.NotNil(t, actual)
+	assert.Equal(t, `expected time is before 2024-01-02T03:04:05Z, got string("2025-01-01T00:00:00Z")`, actual.String())
+
+	assert.Nil(t, matcher.Explain(m, "2023-01-01T00:00:00Z"))
+}
@@ -0,0 +1,186 @@
+package matcher
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/stretchr/testify/assert"
+
+	"go.nhat.io/matcher/v3/format"
+)
+
+// TypedMatcher determines if a value of type T is expected. It is the typed counterpart of Matcher, the mistakes like
+// matching a value of a wrong type are caught at compile time.
+//
+// Use Untyped() and Typed() to convert between a TypedMatcher and a Matcher.
+type TypedMatcher[T any] interface {
+	MatchValue(actual T) (bool, error)
+	Expected() string
+}
+
+var _ TypedMatcher[any] = (*typedEqualMatcher[any])(nil)
+
+// typedEqualMatcher matches by equal value.
+type typedEqualMatcher[T any] struct {
+	expected T
+}
+
+// Expected returns the expectation.
+func (m typedEqualMatcher[T]) Expected() string {
+	return equalMatcher{expected: m.expected}.Expected()
+}
+
+// MatchValue determines if the actual is expected.
+func (m typedEqualMatcher[T]) MatchValue(actual T) (bool, error) {
+	return assert.ObjectsAreEqual(m.expected, actual), nil
+}
+
+func (m typedEqualMatcher[T]) Format(s fmt.State, r rune) {
+	format.Format(s, r, m.expected)
+}
+
+var _ TypedMatcher[any] = (*typedFuncMatcher[any])(nil)
+
+// typedFuncMatcher matches by calling a function.
+type typedFuncMatcher[T any] struct {
+	expected string
+	match    func(actual T) bool
+}
+
+// Expected returns the expectation.
+func (m typedFuncMatcher[T]) Expected() string {
+	return m.expected
+}
+
+// MatchValue determines if the actual is expected.
+func (m typedFuncMatcher[T]) MatchValue(actual T) (bool, error) {
+	return m.match(actual), nil
+}
+
+func (m typedFuncMatcher[T]) Format(s fmt.State, _ rune) {
+	_, _ = fmt.Fprintf(s, "<%s>", m.expected) //nolint: errcheck
+}
+
+var _ TypedMatcher[any] = (*typedMatcher[any])(nil)
+
+// typedMatcher is a TypedMatcher backed by a Matcher.
+type typedMatcher[T any] struct {
+	matcher Matcher
+}
+
+// Expected returns the expectation.
+func (m typedMatcher[T]) Expected() string {
+	return m.matcher.Expected()
+}
+
+// MatchValue determines if the actual is expected.
+func (m typedMatcher[T]) MatchValue(actual T) (bool, error) {
+	return m.matcher.Match(actual)
+}
+
+func (m typedMatcher[T]) Format(s fmt.State, r rune) {
+	if f, ok := m.matcher.(fmt.Formatter); ok {
+		f.Format(s, r)
+
+		return
+	}
+
+	_, _ = fmt.Fprintf(s, "<%s>", m.matcher.Expected()) //nolint: errcheck
+}
+
+var (
+	_ Matcher   = (*untypedMatcher[any])(nil)
+	_ Explainer = (*untypedMatcher[any])(nil)
+)
+
+// untypedMatcher is a Matcher backed by a TypedMatcher. The values of other types do not match.
+type untypedMatcher[T any] struct {
+	matcher TypedMatcher[T]
+}
+
+// Expected returns the expectation.
+func (m untypedMatcher[T]) Expected() string {
+	return m.matcher.Expected()
+}
+
+// Match determines if the actual is expected.
+func (m untypedMatcher[T]) Match(actual any) (bool, error) {
+	v, ok := typedVal[T](actual)
+	if !ok {
+		return false, nil
+	}
+
+	return m.matcher.MatchValue(v)
+}
+
+// Explain explains why the actual is not expected.
+func (m untypedMatcher[T]) Explain(actual any) *Mismatch {
+	if _, ok := typedVal[T](actual); !ok {
+		return newMismatch(m, actual, fmt.Sprintf("got %T, not %s", actual, reflect.TypeFor[T]()), nil)
+	}
+
+	return explain(m, actual, "")
+}
+
+func (m untypedMatcher[T]) Format(s fmt.State, r rune) {
+	if f, ok := m.matcher.(fmt.Formatter); ok {
+		f.Format(s, r)
+
+		return
+	}
+
+	_, _ = fmt.Fprintf(s, "<%s>", m.matcher.Expected()) //nolint: errcheck
+}
+
+// typedVal converts the value to T. A nil is the zero value of T if T is an interface, a pointer, a map, a slice, a
+// channel or a function.
+func typedVal[T any](v any) (T, bool) {
+	if t, ok := v.(T); ok {
+		return t, true
+	}
+
+	var zero T
+
+	if v != nil {
+		return zero, false
+	}
+
+	switch reflect.TypeFor[T]().Kind() {
+	case reflect.Interface, reflect.Pointer, reflect.Map, reflect.Slice, reflect.Chan, reflect.Func:
+		return zero, true
+	}
+
+	return zero, false
+}
+
+// EqualTo matches a value of type T by equality, like Equal.
+func EqualTo[T any](expected T) TypedMatcher[T] {
+	return typedEqualMatcher[T]{expected: expected}
+}
+
+// Satisfies matches a value of type T if the function returns true.
+func Satisfies[T any](match func(actual T) bool) TypedMatcher[T] {
+	return typedFuncMatcher[T]{
+		expected: fmt.Sprintf("satisfies func(%s) bool", reflect.TypeFor[T]()),
+		match:    match,
+	}
+}
+
+// Typed converts a Matcher to a TypedMatcher. Untyped(Typed[T](m)) returns m.
+func Typed[T any](m Matcher) TypedMatcher[T] {
+	if m, ok := m.(untypedMatcher[T]); ok {
+		return m.matcher
+	}
+
+	return typedMatcher[T]{matcher: m}
+}
+
+// Untyped converts a TypedMatcher to a Matcher, so it could be used with the other matchers, like And() or Or(). The
+// values that are not of type T do not match. Typed[T](Untyped(m)) returns m.
+func Untyped[T any](m TypedMatcher[T]) Matcher {
+	if m, ok := m.(typedMatcher[T]); ok {
+		return m.matcher
+	}
+
+	return untypedMatcher[T]{matcher: m}
+}
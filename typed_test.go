@@ -0,0 +1,159 @@
+package matcher_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"go.nhat.io/matcher/v3"
+)
+
+func TestEqualTo(t *testing.T) {
+	t.Parallel()
+
+	m := matcher.EqualTo(42)
+
+	result, err := m.MatchValue(42)
+
+	assert.True(t, result)
+	assert.NoError(t, err)
+
+	result, err = m.MatchValue(43)
+
+	assert.False(t, result)
+	assert.NoError(t, err)
+
+	assert.Equal(t, "42", m.Expected())
+	assert.Equal(t, "foobar", matcher.EqualTo("foobar").Expected())
+	assert.Equal(t, `[]string{"foo"}`, fmt.Sprintf("%#v", matcher.EqualTo([]string{"foo"})))
+}
+
+func TestSatisfies(t *testing.T) {
+	t.Parallel()
+
+	m := matcher.Satisfies(func(actual string) bool { return len(actual) > 3 })
+
+	result, err := m.MatchValue("foobar")
+
+	assert.True(t, result)
+	assert.NoError(t, err)
+
+	result, err = m.MatchValue("foo")
+
+	assert.False(t, result)
+	assert.NoError(t, err)
+
+	assert.Equal(t, "satisfies func(string) bool", m.Expected())
+	assert.Equal(t, "<satisfies func(string) bool>", fmt.Sprintf("%v", m))
+}
+
+func TestUntyped(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		scenario        string
+		matcher         matcher.Matcher
+		actual          any
+		expectedResult  bool
+		expectedMessage string
+	}{
+		{
+			scenario:       "matched",
+			matcher:        matcher.Untyped(matcher.EqualTo(42)),
+			actual:         42,
+			expectedResult: true,
+		},
+		{
+			scenario:        "not matched",
+			matcher:         matcher.Untyped(matcher.EqualTo(42)),
+			actual:          43,
+			expectedMessage: "expected 42, got int(43)",
+		},
+		{
+			scenario:        "wrong type",
+			matcher:         matcher.Untyped(matcher.EqualTo(42)),
+			actual:          int64(42),
+			expectedMessage: "expected 42, got int64, not int",
+		},
+		{
+			scenario:        "nil - not nillable",
+			matcher:         matcher.Untyped(matcher.EqualTo(0)),
+			actual:          nil,
+			expectedMessage: "expected 0, got <nil>, not int",
+		},
+		{
+			scenario:       "nil - pointer",
+			matcher:        matcher.Untyped(matcher.Satisfies(func(actual *int) bool { return actual == nil })),
+			actual:         nil,
+			expectedResult: true,
+		},
+		{
+			scenario:       "nil - interface",
+			matcher:        matcher.Untyped(matcher.Satisfies(func(actual error) bool { return actual == nil })),
+			actual:         nil,
+			expectedResult: true,
+		},
+		{
+			scenario:       "interface",
+			matcher:        matcher.Untyped(matcher.Satisfies(func(actual error) bool { return actual.Error() == "foo" })),
+			actual:         errors.New("foo"),
+			expectedResult: true,
+		},
+		{
+			scenario:       "nested",
+			matcher:        matcher.And(matcher.Untyped(matcher.EqualTo("foobar")), matcher.HasPrefix("foo")),
+			actual:         "foobar",
+			expectedResult: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.scenario, func(t *testing.T) {
+			t.Parallel()
+
+			result, err := tc.matcher.Match(tc.actual)
+
+			assert.Equal(t, tc.expectedResult, result)
+			assert.NoError(t, err)
+
+			actual := matcher.Explain(tc.matcher, tc.actual)
+
+			if tc.expectedMessage == "" {
+				assert.Nil(t, actual)
+			} else {
+				assert.Equal(t, tc.expectedMessage, actual.String())
+			}
+		})
+	}
+}
+
+func TestTyped(t *testing.T) {
+	t.Parallel()
+
+	m := matcher.Typed[[]string](matcher.Len(2))
+
+	result, err := m.MatchValue([]string{"foo", "bar"})
+
+	assert.True(t, result)
+	assert.NoError(t, err)
+
+	result, err = m.MatchValue([]string{"foo"})
+
+	assert.False(t, result)
+	assert.NoError(t, err)
+
+	assert.Equal(t, "len is 2", m.Expected())
+	assert.Equal(t, "<len is 2>", fmt.Sprintf("%v", m))
+}
+
+func TestTyped_RoundTrip(t *testing.T) {
+	t.Parallel()
+
+	m := matcher.Or("foo", "bar")
+	tm := matcher.EqualTo("foo")
+
+	assert.Same(t, m, matcher.Untyped(matcher.Typed[string](m)))
+	assert.Equal(t, tm, matcher.Typed[string](matcher.Untyped(tm)))
+}
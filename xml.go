@@ -0,0 +1,342 @@
+package matcher
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"go.nhat.io/matcher/v3/format"
+)
+
+// xmlIgnoreDiff is the placeholder to ignore the value of an attribute, or the content of an element.
+const xmlIgnoreDiff = "<ignore-diff>"
+
+var (
+	errXMLNoRoot        = errors.New("no root element")
+	errXMLMultipleRoots = errors.New("multiple root elements")
+)
+
+// xmlNode is a canonical XML element, or a text if it has no name. The names are qualified with the namespace URIs
+// instead of the prefixes, the namespace declarations are dropped, and the attributes are sorted. The texts are kept in
+// position among the child elements, with their whitespaces collapsed, and the blank texts are dropped.
+type xmlNode struct {
+	name     xml.Name
+	attrs    []xml.Attr
+	text     string
+	children []*xmlNode
+}
+
+// xmlVal gets the XML document of a string, a []byte, or a value that is marshaled to XML.
+func xmlVal(v any) ([]byte, error) {
+	switch v := v.(type) {
+	case string:
+		return []byte(v), nil
+
+	case []byte:
+		return v, nil
+	}
+
+	return xml.Marshal(v)
+}
+
+// parseXML parses and canonicalizes an XML document. The comments, the processing instructions and the directives are
+// ignored.
+func parseXML(b []byte) (*xmlNode, error) {
+	dec := xml.NewDecoder(bytes.NewReader(b))
+
+	var (
+		root  *xmlNode
+		stack []*xmlNode
+		text  strings.Builder
+	)
+
+	// flushText adds the text read since the last tag, so that the texts split by comments are joined.
+	flushText := func() {
+		if t := strings.Join(strings.Fields(text.String()), " "); t != "" && len(stack) > 0 {
+			parent := stack[len(stack)-1]
+			parent.children = append(parent.children, &xmlNode{text: t})
+		}
+
+		text.Reset()
+	}
+
+	for {
+		tok, err := dec.Token()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		switch t := tok.(type) {
+		case xml.StartElement:
+			flushText()
+
+			n := &xmlNode{name: t.Name, attrs: xmlAttrs(t.Attr)}
+
+			switch {
+			case len(stack) > 0:
+				parent := stack[len(stack)-1]
+				parent.children = append(parent.children, n)
+
+			case root != nil:
+				return nil, errXMLMultipleRoots
+
+			default:
+				root = n
+			}
+
+			stack = append(stack, n)
+
+		case xml.EndElement:
+			flushText()
+
+			stack = stack[:len(stack)-1]
+
+		case xml.CharData:
+			text.Write(t)
+		}
+	}
+
+	if root == nil {
+		return nil, errXMLNoRoot
+	}
+
+	return root, nil
+}
+
+// xmlAttrs removes the namespace declarations and sorts the attributes.
+func xmlAttrs(attrs []xml.Attr) []xml.Attr {
+	result := make([]xml.Attr, 0, len(attrs))
+
+	for _, a := range attrs {
+		if a.Name.Space == "xmlns" || (a.Name.Space == "" && a.Name.Local == "xmlns") {
+			continue
+		}
+
+		result = append(result, a)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return xmlName(result[i].Name) < xmlName(result[j].Name)
+	})
+
+	return result
+}
+
+func xmlName(n xml.Name) string {
+	if n.Space == "" {
+		return n.Local
+	}
+
+	return "{" + n.Space + "}" + n.Local
+}
+
+// isText determines if the node is a text.
+func (n *xmlNode) isText() bool {
+	return n.name.Local == ""
+}
+
+// isIgnored determines if the content of the element is "<ignore-diff>".
+func (n *xmlNode) isIgnored() bool {
+	return len(n.children) == 1 && n.children[0].isText() && n.children[0].text == xmlIgnoreDiff
+}
+
+// step returns the location step of the node in a path.
+func (n *xmlNode) step() string {
+	if n.isText() {
+		return "text()"
+	}
+
+	return n.name.Local
+}
+
+// compareXML compares the elements and returns the differences.
+func compareXML(expected, actual *xmlNode, path string) []*Mismatch {
+	if expected.name != actual.name {
+		return []*Mismatch{{Path: path, Expected: "element " + xmlName(expected.name), Reason: "got element " + xmlName(actual.name)}}
+	}
+
+	result := compareXMLAttrs(expected.attrs, actual.attrs, path)
+
+	if expected.isIgnored() {
+		return result
+	}
+
+	if len(expected.children) != len(actual.children) {
+		return append(result, &Mismatch{
+			Path:     path,
+			Expected: fmt.Sprintf("%d child nodes", len(expected.children)),
+			Reason:   fmt.Sprintf("got %d", len(actual.children)),
+		})
+	}
+
+	counts := make(map[xml.Name]int)
+
+	for _, c := range expected.children {
+		counts[c.name]++
+	}
+
+	positions := make(map[xml.Name]int)
+
+	for i, c := range expected.children {
+		positions[c.name]++
+
+		childPath := path + "/" + c.step()
+		if counts[c.name] > 1 {
+			childPath += fmt.Sprintf("[%d]", positions[c.name])
+		}
+
+		result = append(result, compareXMLNode(c, actual.children[i], childPath)...)
+	}
+
+	return result
+}
+
+// compareXMLNode compares the child nodes, which could be elements or texts.
+func compareXMLNode(expected, actual *xmlNode, path string) []*Mismatch {
+	switch {
+	case expected.isText() && actual.isText():
+		if expected.text == xmlIgnoreDiff || expected.text == actual.text {
+			return nil
+		}
+
+		return []*Mismatch{{Path: path, Expected: strconv.Quote(expected.text), Reason: "got " + strconv.Quote(actual.text)}}
+
+	case expected.isText():
+		return []*Mismatch{{Path: path, Expected: strconv.Quote(expected.text), Reason: "got element " + xmlName(actual.name)}}
+
+	case actual.isText():
+		return []*Mismatch{{Path: path, Expected: "element " + xmlName(expected.name), Reason: "got text " + strconv.Quote(actual.text)}}
+	}
+
+	return compareXML(expected, actual, path)
+}
+
+func compareXMLAttrs(expected, actual []xml.Attr, path string) []*Mismatch {
+	var result []*Mismatch
+
+	values := make(map[xml.Name]string, len(actual))
+
+	for _, a := range actual {
+		values[a.Name] = a.Value
+	}
+
+	for _, e := range expected {
+		attrPath := path + "/@" + e.Name.Local
+
+		v, ok := values[e.Name]
+		delete(values, e.Name)
+
+		switch {
+		case !ok:
+			result = append(result, &Mismatch{Path: attrPath, Expected: strconv.Quote(e.Value), Reason: "attribute not found"})
+
+		case e.Value != xmlIgnoreDiff && e.Value != v:
+			result = append(result, &Mismatch{Path: attrPath, Expected: strconv.Quote(e.Value), Reason: "got " + strconv.Quote(v)})
+		}
+	}
+
+	for _, a := range actual {
+		if v, ok := values[a.Name]; ok {
+			result = append(result, &Mismatch{Path: path + "/@" + a.Name.Local, Expected: "no attribute", Reason: "got " + strconv.Quote(v)})
+		}
+	}
+
+	return result
+}
+
+var (
+	_ Matcher   = (*xmlMatcher)(nil)
+	_ Explainer = (*xmlMatcher)(nil)
+)
+
+// xmlMatcher matches by canonical xml with <ignore-diff> support.
+type xmlMatcher struct {
+	expected string
+	root     *xmlNode
+}
+
+// Expected returns the expectation.
+func (m xmlMatcher) Expected() string {
+	return m.expected
+}
+
+// Match determines if the actual is expected.
+func (m xmlMatcher) Match(actual any) (bool, error) {
+	root, ok, err := decodeXML(actual)
+	if !ok || err != nil {
+		return false, err
+	}
+
+	return len(compareXML(m.root, root, "/"+m.root.name.Local)) == 0, nil
+}
+
+// Explain explains why the actual is not expected.
+func (m xmlMatcher) Explain(actual any) *Mismatch {
+	root, ok, err := decodeXML(actual)
+	if err != nil {
+		return newMismatch(m, actual, "", err)
+	}
+
+	if !ok {
+		return newMismatch(m, actual, "got invalid xml", nil)
+	}
+
+	diff := compareXML(m.root, root, "/"+m.root.name.Local)
+	if len(diff) == 0 {
+		return nil
+	}
+
+	result := newMismatch(m, actual, "", nil)
+	result.Children = diff
+
+	return result
+}
+
+func (m xmlMatcher) Format(s fmt.State, r rune) {
+	format.Format(s, r, m.expected)
+}
+
+// decodeXML decodes the actual as a canonical XML document. The ok flag is false if the actual is not a valid XML.
+func decodeXML(actual any) (_ *xmlNode, ok bool, _ error) {
+	actualBytes, err := xmlVal(actual)
+	if err != nil {
+		return nil, false, err
+	}
+
+	root, err := parseXML(actualBytes)
+	if err != nil {
+		return nil, false, nil //nolint: nilerr
+	}
+
+	return root, true, nil
+}
+
+// XML matches two xml documents after canonicalizing them: the order of the attributes, the namespace prefixes, the
+// comments and the processing instructions do not matter, and the whitespaces in the texts are collapsed, so "foo bar"
+// matches " foo\n  bar ". The order of the elements and the texts between them does.
+//
+// The expectation and the actual could be a string or a []byte of XML, or any value that is marshaled to XML. An
+// attribute value or a text "<ignore-diff>", written as "&lt;ignore-diff&gt;", matches any value. If it is the whole
+// content of an element, the child elements are ignored too. The matcher panics if the expectation is invalid.
+func XML(expected any) Matcher {
+	b, err := xmlVal(expected)
+	if err != nil {
+		panic(err)
+	}
+
+	root, err := parseXML(b)
+	if err != nil {
+		panic(err)
+	}
+
+	return xmlMatcher{expected: string(b), root: root}
+}
@@ -0,0 +1,248 @@
+package matcher_test
+
+import (
+	"encoding/xml"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.nhat.io/matcher/v3"
+)
+
+const xmlTestExpected = `<?xml version="1.0" encoding="UTF-8"?>
+<soap:Envelope xmlns:soap="http://schemas.xmlsoap.org/soap/envelope/" xmlns:u="urn:users">
+	<soap:Body>
+		<u:GetUserResponse requestId="&lt;ignore-diff&gt;" status="ok">
+			<u:id>42</u:id>
+			<u:name> John </u:name>
+			<u:tags><u:tag>a</u:tag><u:tag>b</u:tag></u:tags>
+			<u:meta>&lt;ignore-diff&gt;</u:meta>
+		</u:GetUserResponse>
+	</soap:Body>
+</soap:Envelope>`
+
+func TestXML_Match(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		scenario string
+		actual   any
+		expected bool
+	}{
+		{
+			scenario: "same",
+			actual:   xmlTestExpected,
+			expected: true,
+		},
+		{
+			scenario: "canonical",
+			actual: []byte(`<!-- response --><Envelope xmlns="http://schemas.xmlsoap.org/soap/envelope/"><Body>` +
+				`<x:GetUserResponse xmlns:x="urn:users" status="ok" requestId="123">` +
+				`<x:id>42</x:id><x:name>John</x:name><x:tags><x:tag>a</x:tag><x:tag>b</x:tag></x:tags>` +
+				`<x:meta><x:created>today</x:created></x:meta>` +
+				`</x:GetUserResponse></Body></Envelope>`),
+			expected: true,
+		},
+		{
+			scenario: "different namespace",
+			actual: `<soap:Envelope xmlns:soap="http://schemas.xmlsoap.org/soap/envelope/" xmlns:u="urn:accounts"><soap:Body>` +
+				`<u:GetUserResponse requestId="1" status="ok"><u:id>42</u:id><u:name>John</u:name>` +
+				`<u:tags><u:tag>a</u:tag><u:tag>b</u:tag></u:tags><u:meta/></u:GetUserResponse></soap:Body></soap:Envelope>`,
+		},
+		{
+			scenario: "different order",
+			actual: `<soap:Envelope xmlns:soap="http://schemas.xmlsoap.org/soap/envelope/" xmlns:u="urn:users"><soap:Body>` +
+				`<u:GetUserResponse requestId="1" status="ok"><u:id>42</u:id><u:name>John</u:name>` +
+				`<u:tags><u:tag>b</u:tag><u:tag>a</u:tag></u:tags><u:meta/></u:GetUserResponse></soap:Body></soap:Envelope>`,
+		},
+		{
+			scenario: "missing attribute",
+			actual: `<soap:Envelope xmlns:soap="http://schemas.xmlsoap.org/soap/envelope/" xmlns:u="urn:users"><soap:Body>` +
+				`<u:GetUserResponse status="ok"><u:id>42</u:id><u:name>John</u:name>` +
+				`<u:tags><u:tag>a</u:tag><u:tag>b</u:tag></u:tags><u:meta/></u:GetUserResponse></soap:Body></soap:Envelope>`,
+		},
+		{
+			scenario: "invalid xml",
+			actual:   `<soap:Envelope>`,
+		},
+		{
+			scenario: "not xml",
+			actual:   `foobar`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.scenario, func(t *testing.T) {
+			t.Parallel()
+
+			result, err := matcher.XML(xmlTestExpected).Match(tc.actual)
+
+			assert.Equal(t, tc.expected, result)
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestXML_Match_Text(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		scenario string
+		expected string
+		actual   string
+		matched  bool
+	}{
+		{
+			scenario: "whitespaces",
+			expected: `<a>foo bar</a>`,
+			actual:   "<a>\n\tfoo   bar\n</a>",
+			matched:  true,
+		},
+		{
+			scenario: "mixed content",
+			expected: `<a>x <b/> y</a>`,
+			actual:   `<a>x<b/>y</a>`,
+			matched:  true,
+		},
+		{
+			scenario: "mixed content - moved text",
+			expected: `<a>x<b/>y</a>`,
+			actual:   `<a>xy<b/></a>`,
+		},
+		{
+			scenario: "mixed content - joined text",
+			expected: `<a>foo bar</a>`,
+			actual:   `<a>foobar</a>`,
+		},
+		{
+			scenario: "comment",
+			expected: `<a>foobar</a>`,
+			actual:   `<a>foo<!-- comment -->bar</a>`,
+			matched:  true,
+		},
+		{
+			scenario: "ignore diff - part of a text",
+			expected: `<a>Hello &lt;ignore-diff&gt;<b/></a>`,
+			actual:   `<a>Hello John<b/></a>`,
+		},
+		{
+			scenario: "ignore diff - text",
+			expected: `<a>&lt;ignore-diff&gt;<b/></a>`,
+			actual:   `<a>John<b/></a>`,
+			matched:  true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.scenario, func(t *testing.T) {
+			t.Parallel()
+
+			result, err := matcher.XML(tc.expected).Match(tc.actual)
+
+			assert.Equal(t, tc.matched, result)
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestXML_Match_Struct(t *testing.T) {
+	t.Parallel()
+
+	type user struct {
+		XMLName xml.Name `xml:"user"`
+		ID      int      `xml:"id,attr"`
+		Name    string   `xml:"name"`
+	}
+
+	m := matcher.XML(`<user id="42"><name>John</name></user>`)
+
+	result, err := m.Match(user{ID: 42, Name: "John"})
+
+	assert.True(t, result)
+	require.NoError(t, err)
+
+	result, err = matcher.XML(user{ID: 42, Name: "John"}).Match(`<user id="42"> <name>John</name> </user>`)
+
+	assert.True(t, result)
+	require.NoError(t, err)
+
+	result, err = m.Match(make(chan int))
+
+	assert.False(t, result)
+	require.EqualError(t, err, `xml: unsupported type: chan int`)
+}
+
+func TestXML_InvalidExpectation(t *testing.T) {
+	t.Parallel()
+
+	assert.PanicsWithError(t, `no root element`, func() {
+		matcher.XML(``)
+	})
+
+	assert.PanicsWithError(t, `multiple root elements`, func() {
+		matcher.XML(`<a/><b/>`)
+	})
+
+	assert.Panics(t, func() {
+		matcher.XML(`<a>`)
+	})
+}
+
+func TestXML_Explain(t *testing.T) {
+	t.Parallel()
+
+	m := matcher.XML(`<order id="1" xmlns="urn:orders"><item sku="a">2</item><item sku="b">1</item><note>&lt;ignore-diff&gt;</note></order>`)
+
+	actual := matcher.Explain(m, `<order xmlns="urn:orders" status="new"><item sku="a">3</item><item>1</item><note>x</note></order>`)
+	expected := `expected <order id="1" xmlns="urn:orders"><item sku="a">2</item><item sku="b">1</item><note>&lt;ignore-diff&gt;</note></order>, got string("<order xmlns=\"urn:orders\" status=\"new\"><item sku=\"a\">3</item><item>1</item><note>x</note></order>")
+  /order/@id: expected "1", attribute not found
+  /order/@status: expected no attribute, got "new"
+  /order/item[1]/text(): expected "2", got "3"
+  /order/item[2]/@sku: expected "b", attribute not found`
+
+	require.NotNil(t, actual)
+	assert.Equal(t, expected, actual.String())
+
+	actual = matcher.Explain(m, `<order xmlns="urn:other"/>`)
+
+	require.NotNil(t, actual)
+	assert.Equal(t, `expected <order id="1" xmlns="urn:orders"><item sku="a">2</item><item sku="b">1</item><note>&lt;ignore-diff&gt;</note></order>, got string("<order xmlns=\"urn:other\"/>")
+  /order: expected element {urn:orders}order, got element {urn:other}order`, actual.String())
+
+	actual = matcher.Explain(matcher.XML(`<a><b/></a>`), `<a><b/><b/></a>`)
+
+	require.NotNil(t, actual)
+	assert.Equal(t, `expected <a><b/></a>, got string("<a><b/><b/></a>")
+  /a: expected 1 child nodes, got 2`, actual.String())
+
+	actual = matcher.Explain(matcher.XML(`<a>x<b/>y <c/> z</a>`), `<a>xy<b/><c/>z</a>`)
+
+	require.NotNil(t, actual)
+	assert.Equal(t, `expected <a>x<b/>y <c/> z</a>, got string("<a>xy<b/><c/>z</a>")
+  /a: expected 5 child nodes, got 4`, actual.String())
+
+	actual = matcher.Explain(matcher.XML(`<a>x<b/>y</a>`), `<a><b/>x<b/></a>`)
+
+	require.NotNil(t, actual)
+	assert.Equal(t, `expected <a>x<b/>y</a>, got string("<a><b/>x<b/></a>")
+  /a/text()[1]: expected "x", got element b
+  /a/b: expected element b, got text "x"
+  /a/text()[2]: expected "y", got element b`, actual.String())
+
+	actual = matcher.Explain(m, `<order`)
+
+	require.NotNil(t, actual)
+	assert.Equal(t, `expected <order id="1" xmlns="urn:orders"><item sku="a">2</item><item sku="b">1</item><note>&lt;ignore-diff&gt;</note></order>, got invalid xml`, actual.String())
+}
+
+func TestXML_Format(t *testing.T) {
+	t.Parallel()
+
+	m := matcher.XML(`<a/>`)
+
+	assert.Equal(t, `string(<a/>)`, fmt.Sprintf("%v", m))
+	assert.Equal(t, `<a/>`, fmt.Sprintf("%s", m))
+	assert.Equal(t, `<a/>`, m.Expected())
+}
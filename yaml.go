@@ -0,0 +1,173 @@
+package matcher
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"gopkg.in/yaml.v3"
+
+	"go.nhat.io/matcher/v3/format"
+)
+
+// yamlVal converts a YAML document, or a value that is marshaled to YAML, to JSON.
+func yamlVal(v any) ([]byte, error) {
+	var (
+		b   []byte
+		err error
+	)
+
+	switch v := v.(type) {
+	case string:
+		b = []byte(v)
+
+	case []byte:
+		b = v
+
+	default:
+		if b, err = marshalYAML(v); err != nil {
+			return nil, err
+		}
+	}
+
+	var doc any
+
+	if err := yaml.Unmarshal(b, &doc); err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(yamlToJSON(doc))
+}
+
+// marshalYAML marshals the value to YAML. The panics of yaml.Marshal on the unsupported types are returned as errors.
+func marshalYAML(v any) (_ []byte, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("yaml: %v", r)
+		}
+	}()
+
+	return yaml.Marshal(v)
+}
+
+// yamlToJSON converts the maps with non-string keys, which are not supported by JSON, to map[string]any.
+func yamlToJSON(v any) any {
+	switch v := v.(type) {
+	case map[string]any:
+		for k, e := range v {
+			v[k] = yamlToJSON(e)
+		}
+
+		return v
+
+	case map[any]any:
+		result := make(map[string]any, len(v))
+
+		for k, e := range v {
+			result[fmt.Sprint(k)] = yamlToJSON(e)
+		}
+
+		return result
+
+	case []any:
+		for i, e := range v {
+			v[i] = yamlToJSON(e)
+		}
+
+		return v
+	}
+
+	return v
+}
+
+var (
+	_ Matcher   = (*yamlMatcher)(nil)
+	_ Explainer = (*yamlMatcher)(nil)
+)
+
+// yamlMatcher matches by yaml with <ignore-diff> support.
+type yamlMatcher struct {
+	expected string
+	json     jsonMatcher
+}
+
+// Expected returns the expectation.
+func (m yamlMatcher) Expected() string {
+	return m.expected
+}
+
+// Match determines if the actual is expected.
+func (m yamlMatcher) Match(actual any) (bool, error) {
+	actualBytes, ok, err := yamlActual(actual)
+	if !ok || err != nil {
+		return false, err
+	}
+
+	return m.json.Match(actualBytes)
+}
+
+// Explain explains why the actual is not expected.
+func (m yamlMatcher) Explain(actual any) *Mismatch {
+	actualBytes, ok, err := yamlActual(actual)
+	if err != nil {
+		return newMismatch(m, actual, "", err)
+	}
+
+	if !ok {
+		return newMismatch(m, actual, "got invalid yaml", nil)
+	}
+
+	c := m.json.Explain(actualBytes)
+	if c == nil {
+		return nil
+	}
+
+	return newMismatch(m, actual, c.Reason, c.Err)
+}
+
+func (m yamlMatcher) Format(s fmt.State, r rune) {
+	format.Format(s, r, m.expected)
+}
+
+// yamlActual converts the actual to JSON. The ok flag is false if the actual is not a valid YAML document.
+func yamlActual(actual any) (_ []byte, ok bool, _ error) {
+	b, err := yamlVal(actual)
+	if err == nil {
+		return b, true, nil
+	}
+
+	switch actual.(type) {
+	case string, []byte:
+		return nil, false, nil
+	}
+
+	return nil, false, err
+}
+
+// YAML matches two yaml documents with <ignore-diff> support. The documents are compared structurally, so the order of
+// the keys does not matter.
+//
+// The expectation and the actual could be a string or a []byte of YAML, or any value that is marshaled to YAML. The
+// matcher panics if the expectation is invalid.
+func YAML(expected any) Matcher {
+	ex, err := yamlVal(expected)
+	if err != nil {
+		panic(err)
+	}
+
+	var text string
+
+	switch v := expected.(type) {
+	case string:
+		text = v
+
+	case []byte:
+		text = string(v)
+
+	default:
+		b, _ := marshalYAML(v) //nolint: errcheck
+
+		text = string(b)
+	}
+
+	return yamlMatcher{expected: text, json: jsonMatcher{expected: string(ex)}}
+}
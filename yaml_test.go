@@ -0,0 +1,159 @@
+package matcher_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.nhat.io/matcher/v3"
+)
+
+const yamlTestExpected = `name: app
+version: <ignore-diff>
+ports:
+  - 80
+  - 443
+labels:
+  tier: web
+`
+
+func TestYAML_Match(t *testing.T) {
+	t.Parallel()
+
+	type config struct {
+		Name    string            `yaml:"name"`
+		Version string            `yaml:"version"`
+		Ports   []int             `yaml:"ports"`
+		Labels  map[string]string `yaml:"labels"`
+	}
+
+	testCases := []struct {
+		scenario string
+		expected any
+		actual   any
+		result   bool
+	}{
+		{
+			scenario: "same",
+			expected: yamlTestExpected,
+			actual:   yamlTestExpected,
+			result:   true,
+		},
+		{
+			scenario: "key order",
+			expected: yamlTestExpected,
+			actual:   "labels: {tier: web}\nports: [80, 443]\nversion: 1.2.3\nname: app\n",
+			result:   true,
+		},
+		{
+			scenario: "bytes",
+			expected: []byte(yamlTestExpected),
+			actual:   []byte("name: app\nversion: 2\nports: [80, 443]\nlabels: {tier: web}"),
+			result:   true,
+		},
+		{
+			scenario: "struct",
+			expected: yamlTestExpected,
+			actual:   config{Name: "app", Version: "1", Ports: []int{80, 443}, Labels: map[string]string{"tier": "web"}},
+			result:   true,
+		},
+		{
+			scenario: "struct expectation",
+			expected: config{Name: "app", Ports: []int{80}},
+			actual:   "name: app\nversion: ''\nports: [80]\nlabels: {}\n",
+			result:   true,
+		},
+		{
+			scenario: "non-string keys",
+			expected: "1: one\ntrue: yes\n",
+			actual:   map[int]string{1: "one"},
+		},
+		{
+			scenario: "different value",
+			expected: yamlTestExpected,
+			actual:   "name: app\nversion: 1\nports: [80, 8080]\nlabels: {tier: web}\n",
+		},
+		{
+			scenario: "extra key",
+			expected: yamlTestExpected,
+			actual:   yamlTestExpected + "replicas: 3\n",
+		},
+		{
+			scenario: "invalid yaml",
+			expected: yamlTestExpected,
+			actual:   "ports: [80",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.scenario, func(t *testing.T) {
+			t.Parallel()
+
+			result, err := matcher.YAML(tc.expected).Match(tc.actual)
+
+			assert.Equal(t, tc.result, result)
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestYAML_Match_Error(t *testing.T) {
+	t.Parallel()
+
+	result, err := matcher.YAML(yamlTestExpected).Match(make(chan int))
+
+	assert.False(t, result)
+	require.EqualError(t, err, `yaml: cannot marshal type: chan int`)
+}
+
+func TestYAML_InvalidExpectation(t *testing.T) {
+	t.Parallel()
+
+	assert.Panics(t, func() {
+		matcher.YAML("a: [1")
+	})
+}
+
+func TestYAML_Explain(t *testing.T) {
+	t.Parallel()
+
+	m := matcher.YAML("a: 1\nb: [1, 2]\n")
+
+	actual := matcher.Explain(m, "b: [1, 3]\na: 1\n")
+	expected := `expected a: 1
+b: [1, 2]
+, not equal:
+ {
+   "a": 1,
+   "b": [
+     1,
+-    2
++    3
+   ]
+ }
+`
+
+	require.NotNil(t, actual)
+	assert.Equal(t, expected, actual.String())
+
+	actual = matcher.Explain(m, "a: [1")
+
+	require.NotNil(t, actual)
+	assert.Equal(t, "expected a: 1\nb: [1, 2]\n, got invalid yaml", actual.String())
+
+	assert.Nil(t, matcher.Explain(m, "b: [1, 2]\na: 1"))
+}
+
+func TestYAML_Format(t *testing.T) {
+	t.Parallel()
+
+	m := matcher.YAML("a: 1\n")
+
+	assert.Equal(t, "string(a: 1\n)", fmt.Sprintf("%v", m))
+	assert.Equal(t, "a: 1\n", fmt.Sprintf("%s", m))
+	assert.Equal(t, `"a: 1\n"`, fmt.Sprintf("%q", m))
+	assert.Equal(t, `string("a: 1\n")`, fmt.Sprintf("%#v", m))
+	assert.Equal(t, "a: 1\n", m.Expected())
+}